@@ -0,0 +1,118 @@
+package did
+
+import (
+	"fmt"
+	"strings"
+)
+
+func isFragmentCharacter(c byte) bool {
+	return isPathCharacter(c) || c == '/' || c == '?'
+}
+
+func isPathCharacter(c byte) bool {
+	return isUnreserved(c) || isSubDelimiter(c) || c == ':' || c == '@'
+}
+
+// percentEncode percent-encodes all characters for which allowed returns false.
+func percentEncode(s string, allowed func(c byte) bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; allowed(c) {
+			sb.WriteByte(c)
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%%%02X", s[i]))
+	}
+	return sb.String()
+}
+
+// URLBuilder builds DID URLs from raw, unencoded, components. All components are percent-encoded where needed and the
+// result is validated against the DID URL syntax.
+type URLBuilder struct {
+	method     string
+	methodIDs  []string
+	parameters []Parameter
+	path       []string
+	query      Query
+	fragment   *string
+}
+
+// NewURLBuilder returns a builder for DID URLs of the given method.
+func NewURLBuilder(method string) *URLBuilder {
+	return &URLBuilder{method: method}
+}
+
+// Build returns the DID URL.
+func (b *URLBuilder) Build() (*DIDURL, error) {
+	u := DIDURL{
+		Method: b.method,
+	}
+	for _, id := range b.methodIDs {
+		u.MethodIDs = append(u.MethodIDs, percentEncode(id, isIDCharacter))
+	}
+	for _, p := range b.parameters {
+		u.Parameters = append(u.Parameters, Parameter{
+			Key:   percentEncode(p.Key, isParameterNameCharacter),
+			Value: percentEncode(p.Value, isParameterNameCharacter),
+		})
+	}
+	for _, segment := range b.path {
+		u.Path += "/" + percentEncode(segment, isPathCharacter)
+	}
+	u.SetQuery(b.query)
+	if b.fragment != nil {
+		u.Fragment = percentEncode(*b.fragment, isFragmentCharacter)
+	}
+
+	// Validate the result against the DID URL syntax.
+	return ParseDIDURL(u.String())
+}
+
+// BuildDID returns the DID, it fails if any of the DID URL components (parameters, path, query or fragment) are set.
+func (b *URLBuilder) BuildDID() (*DID, error) {
+	u, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	if !u.IsDID() {
+		return nil, fmt.Errorf("invalid DID: %s: unexpected DID URL components", u.String())
+	}
+	d := u.DID()
+	return &d, nil
+}
+
+// Fragment sets the fragment.
+func (b *URLBuilder) Fragment(fragment string) *URLBuilder {
+	b.fragment = &fragment
+	return b
+}
+
+// MethodID appends the given segments to the method-specific identifier. Segments are separated by colons.
+func (b *URLBuilder) MethodID(segments ...string) *URLBuilder {
+	b.methodIDs = append(b.methodIDs, segments...)
+	return b
+}
+
+// Parameter appends a (matrix) DID parameter, e.g. ";key=value".
+func (b *URLBuilder) Parameter(key, value string) *URLBuilder {
+	b.parameters = append(b.parameters, Parameter{Key: key, Value: value})
+	return b
+}
+
+// Path appends the given segments to the path.
+func (b *URLBuilder) Path(segments ...string) *URLBuilder {
+	b.path = append(b.path, segments...)
+	return b
+}
+
+// Query appends a query parameter, e.g. "?key=value".
+func (b *URLBuilder) Query(key, value string) *URLBuilder {
+	b.query.Add(key, value)
+	return b
+}
+
+// QueryKey appends a query parameter without a value, e.g. "?key".
+func (b *URLBuilder) QueryKey(key string) *URLBuilder {
+	b.query = append(b.query, Parameter{Key: key, NoValue: true})
+	return b
+}
@@ -0,0 +1,80 @@
+package did_test
+
+import (
+	"fmt"
+	"github.com/0x51-dev/did/did"
+	"testing"
+)
+
+func ExampleURLBuilder() {
+	u, _ := did.NewURLBuilder("web").
+		MethodID("localhost:8443", "user", "alice smith").
+		Path("files", "résumé.pdf").
+		Query("versionTime", "2021-05-10T17:00:00Z").
+		Fragment("page=1").
+		Build()
+	fmt.Println(u.String())
+	// Output:
+	// did:web:localhost%3A8443:user:alice%20smith/files/r%C3%A9sum%C3%A9.pdf?versionTime=2021-05-10T17:00:00Z#page=1
+}
+
+func TestURLBuilder(t *testing.T) {
+	for _, test := range []struct {
+		builder  *did.URLBuilder
+		expected string
+	}{
+		{did.NewURLBuilder("example").MethodID("123"), "did:example:123"},
+		{did.NewURLBuilder("example").MethodID("a", "", "b"), "did:example:a::b"},
+		{did.NewURLBuilder("example").MethodID("123").Parameter("service", "agent/1"), "did:example:123;service=agent%2F1"},
+		{did.NewURLBuilder("example").MethodID("123").Query("relativeRef", "/a b&c"), "did:example:123?relativeRef=/a%20b%26c"},
+		{did.NewURLBuilder("example").MethodID("123").QueryKey("flag").Query("a", ""), "did:example:123?flag&a="},
+		{did.NewURLBuilder("example").MethodID("123").Fragment("key 1#2"), "did:example:123#key%201%232"},
+		{did.NewURLBuilder("example").MethodID("100%"), "did:example:100%25"},
+	} {
+		u, err := test.builder.Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := u.String(); s != test.expected {
+			t.Error(s, test.expected)
+		}
+	}
+}
+
+func TestURLBuilder_queryKey(t *testing.T) {
+	u, err := did.NewURLBuilder("example").MethodID("123").QueryKey("flag").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.QueryParameters()
+	if len(q) != 1 || q[0] != (did.Parameter{Key: "flag", NoValue: true}) {
+		t.Fatal(q)
+	}
+	b := did.NewURLBuilder("example").MethodID("123")
+	for _, p := range q {
+		if p.NoValue {
+			b.QueryKey(p.Key)
+		} else {
+			b.Query(p.Key, p.Value)
+		}
+	}
+	if v, err := b.Build(); err != nil || v.String() != "did:example:123?flag" {
+		t.Error(v, err)
+	}
+}
+
+func TestURLBuilder_invalid(t *testing.T) {
+	for _, builder := range []*did.URLBuilder{
+		did.NewURLBuilder("Example").MethodID("123"),
+		did.NewURLBuilder("").MethodID("123"),
+		did.NewURLBuilder("example"),
+		did.NewURLBuilder("example").MethodID("123", ""),
+	} {
+		if u, err := builder.Build(); err == nil {
+			t.Errorf("expected error, got %s", u.String())
+		}
+	}
+	if _, err := did.NewURLBuilder("example").MethodID("123").Fragment("key-1").BuildDID(); err == nil {
+		t.Error("expected error")
+	}
+}
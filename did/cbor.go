@@ -0,0 +1,53 @@
+package did
+
+import (
+	"encoding/json"
+	"github.com/0x51-dev/did/internal/cbor"
+	"github.com/0x51-dev/did/internal/jsonutils"
+)
+
+// MediaTypeCBOR is the media type of the CBOR representation of DID documents.
+const MediaTypeCBOR = "application/did+cbor"
+
+// MarshalCBOR returns the CBOR representation (application/did+cbor) of the document: the data model of its JSON
+// representation, including the extensions, in the deterministic encoding of RFC 8949. Equal documents have equal
+// encodings.
+// DOCS: https://www.rfc-editor.org/rfc/rfc8949#section-4.2
+func (d *Document) MarshalCBOR() ([]byte, error) {
+	raw, err := d.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	v, err := jsonutils.Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	return cbor.Marshal(v)
+}
+
+// UnmarshalCBOR decodes the CBOR representation of a DID document, see ParseDocumentCBOR.
+func (d *Document) UnmarshalCBOR(raw []byte) error {
+	v, err := cbor.Unmarshal(raw)
+	if err != nil {
+		return err
+	}
+	raw, err = json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return d.UnmarshalJSON(raw)
+}
+
+// ParseDocumentCBOR parses the CBOR representation (application/did+cbor) of a DID document. The encoding does not have
+// to be deterministic, but the data must be representable in JSON: e.g. map keys must be text strings, and byte strings
+// are not supported.
+func ParseDocumentCBOR(raw []byte, options ...ParseOptions) (*Document, error) {
+	var doc Document
+	if err := doc.UnmarshalCBOR(raw); err != nil {
+		return nil, err
+	}
+	if err := doc.validateParsed(options); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
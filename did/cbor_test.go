@@ -0,0 +1,173 @@
+package did
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/0x51-dev/did/internal/jsonutils"
+	"math/big"
+	"testing"
+)
+
+func TestDocument_MarshalCBOR(t *testing.T) {
+	for _, raw := range [][]byte{example1, example9, example10, example11, example13, example14, example20, example21, example22} {
+		doc, err := ParseDocument(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded, err := doc.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := ParseDocumentCBOR(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := doc.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := decoded.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Numbers are equal by value, e.g. 1.50 becomes 1.5.
+		if !equalValues(decode(t, expected), decode(t, actual)) {
+			t.Errorf("expected %s, got %s", expected, actual)
+		}
+		// The encoding is deterministic.
+		again, err := decoded.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, again) {
+			t.Errorf("expected %x, got %x", encoded, again)
+		}
+	}
+}
+
+func TestDocument_MarshalCBOR_extensions(t *testing.T) {
+	doc, err := ParseDocument([]byte(`{
+		"id": "did:example:123",
+		"ext": {"n": [1, -1.5, 0.1, 1e400, 18446744073709551616], "b": true, "null": null},
+		"service": [{"id": "#s", "type": "Example", "serviceEndpoint": {"uri": "https://example.com"}, "priority": 1}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := doc.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ParseDocumentCBOR(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ext struct {
+		N []json.Number `json:"n"`
+	}
+	if err := json.Unmarshal(decoded.Extensions["ext"], &ext); err != nil {
+		t.Fatal(err)
+	}
+	for i, n := range []string{"1", "-1.5", "0.1", "1e400", "18446744073709551616"} {
+		if !equalNumbers(ext.N[i], json.Number(n)) {
+			t.Errorf("expected %s, got %s", n, ext.N[i])
+		}
+	}
+	if string(decoded.Service[0].Extensions["priority"]) != "1" {
+		t.Error(decoded.Service[0].Extensions)
+	}
+}
+
+func TestDocument_MarshalCBOR_deterministic(t *testing.T) {
+	doc, err := ParseDocument([]byte(`{"id": "did:example:123", "alsoKnownAs": ["https://example.com"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := doc.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// {"id": "did:example:123", "alsoKnownAs": ["https://example.com"]}, the shorter key first.
+	expected := "a2" +
+		"626964" + "6f6469643a6578616d706c653a313233" +
+		"6b616c736f4b6e6f776e4173" + "81" + "7368747470733a2f2f6578616d706c652e636f6d"
+	if h := hex.EncodeToString(encoded); h != expected {
+		t.Errorf("expected %s, got %s", expected, h)
+	}
+}
+
+func TestParseDocumentCBOR(t *testing.T) {
+	// Indefinite-length map {"id": "did:example:123"}.
+	raw, _ := hex.DecodeString("bf626964" + "6f6469643a6578616d706c653a313233" + "ff")
+	doc, err := ParseDocumentCBOR(raw, ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.ID.String() != "did:example:123" {
+		t.Error(doc.ID)
+	}
+	for _, test := range []string{
+		"a1626964" + "43010203", // Byte string.
+		"a16269640a",            // Not a DID.
+		"82626964" + "6f6469643a6578616d706c653a313233", // Not a map.
+	} {
+		raw, _ := hex.DecodeString(test)
+		if _, err := ParseDocumentCBOR(raw); err == nil {
+			t.Errorf("%s: expected an error", test)
+		}
+	}
+	// {"id": "did:example:123", "controller": "invalid"}
+	raw, _ = hex.DecodeString("a2626964" + "6f6469643a6578616d706c653a313233" + "6a636f6e74726f6c6c6572" + "67696e76616c6964")
+	if _, err := ParseDocumentCBOR(raw, ParseOptions{Strict: true}); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func decode(t *testing.T, raw []byte) any {
+	v, err := jsonutils.Decode(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// equalValues compares decoded JSON values, of which the numbers are compared by value.
+func equalValues(a, b any) bool {
+	switch a := a.(type) {
+	case map[string]any:
+		m, ok := b.(map[string]any)
+		if !ok || len(a) != len(m) {
+			return false
+		}
+		for k, v := range a {
+			if w, ok := m[k]; !ok || !equalValues(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		s, ok := b.([]any)
+		if !ok || len(a) != len(s) {
+			return false
+		}
+		for i := range a {
+			if !equalValues(a[i], s[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		n, ok := b.(json.Number)
+		return ok && equalNumbers(a, n)
+	default:
+		return a == b
+	}
+}
+
+// equalNumbers compares JSON numbers by value, e.g. 1e400 and 1E+400.
+func equalNumbers(a, b json.Number) bool {
+	x, ok := new(big.Rat).SetString(string(a))
+	y, ok2 := new(big.Rat).SetString(string(b))
+	return ok && ok2 && x.Cmp(y) == 0
+}
@@ -0,0 +1,139 @@
+package did
+
+import (
+	"encoding/json"
+)
+
+// VerificationMethodCloner can be implemented by custom IVerificationMethods, so that Document.Clone can copy them.
+type VerificationMethodCloner interface {
+	CloneVerificationMethod() IVerificationMethod
+}
+
+func cloneContexts(contexts []Context) []Context {
+	if contexts == nil {
+		return nil
+	}
+	c := make([]Context, len(contexts))
+	for i, context := range contexts {
+		c[i] = context.Clone()
+	}
+	return c
+}
+
+func cloneDIDs(dids []DID) []DID {
+	if dids == nil {
+		return nil
+	}
+	c := make([]DID, len(dids))
+	for i, did := range dids {
+		c[i] = did.Clone()
+	}
+	return c
+}
+
+func cloneRawMessages(m map[string]json.RawMessage) map[string]json.RawMessage {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]json.RawMessage, len(m))
+	for k, v := range m {
+		c[k] = append(json.RawMessage(nil), v...)
+	}
+	return c
+}
+
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
+
+// Clone returns a deep copy of the document. Custom IVerificationMethods are only copied if they implement the
+// VerificationMethodCloner interface, otherwise they are shared.
+func (d *Document) Clone() *Document {
+	c := Document{
+		Context:     cloneContexts(d.Context),
+		ID:          d.ID.Clone(),
+		AlsoKnownAs: cloneStrings(d.AlsoKnownAs),
+		Controller:  cloneDIDs(d.Controller),
+		Extensions:  cloneRawMessages(d.Extensions),
+	}
+	if d.VerificationMethod != nil {
+		c.VerificationMethod = make(VerificationMethods, len(d.VerificationMethod))
+		for i, method := range d.VerificationMethod {
+			c.VerificationMethod[i] = method.Clone()
+		}
+	}
+	for _, r := range Relationships {
+		methods := *d.relationship(r)
+		if methods == nil {
+			continue
+		}
+		cloned := make([]IVerificationMethod, len(methods))
+		for i, method := range methods {
+			switch method := method.(type) {
+			case *VerificationMethod:
+				m := method.Clone()
+				cloned[i] = &m
+			case *RelativeVerificationMethod:
+				cloned[i] = &RelativeVerificationMethod{RelativeURL: method.RelativeURL}
+			case VerificationMethodCloner:
+				cloned[i] = method.CloneVerificationMethod()
+			default:
+				cloned[i] = method
+			}
+		}
+		*c.relationship(r) = cloned
+	}
+	if d.Service != nil {
+		c.Service = make([]Service, len(d.Service))
+		for i, service := range d.Service {
+			c.Service[i] = service.Clone()
+		}
+	}
+	return &c
+}
+
+// Clone returns a deep copy of the DID.
+func (d DID) Clone() DID {
+	return DID{
+		Method:    d.Method,
+		MethodIDs: cloneStrings(d.MethodIDs),
+	}
+}
+
+// Clone returns a deep copy of the service.
+func (s Service) Clone() Service {
+	return Service{
+		ID:              s.ID,
+		Type:            cloneStrings(s.Type),
+		ServiceEndpoint: s.ServiceEndpoint.Clone(),
+		Extensions:      cloneRawMessages(s.Extensions),
+	}
+}
+
+// Clone returns a deep copy of the service endpoint.
+func (e ServiceEndpoint) Clone() ServiceEndpoint {
+	c := ServiceEndpoint{
+		URI: e.URI,
+		Map: cloneRawMessages(e.Map),
+	}
+	if e.Set != nil {
+		c.Set = make([]ServiceEndpoint, len(e.Set))
+		for i, entry := range e.Set {
+			c.Set[i] = entry.Clone()
+		}
+	}
+	return c
+}
+
+// Clone returns a deep copy of the verification method.
+func (v VerificationMethod) Clone() VerificationMethod {
+	c := v
+	if v.PublicKeyJwk != nil {
+		c.PublicKeyJwk = v.PublicKeyJwk.Clone()
+	}
+	c.Extensions = cloneRawMessages(v.Extensions)
+	return c
+}
@@ -0,0 +1,135 @@
+package did
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type customVerificationMethod struct {
+	method VerificationMethod
+}
+
+func (c *customVerificationMethod) CloneVerificationMethod() IVerificationMethod {
+	return &customVerificationMethod{method: c.method.Clone()}
+}
+
+func (c *customVerificationMethod) Get(_ []VerificationMethod) *VerificationMethod {
+	return &c.method
+}
+
+func TestDocument_Clone(t *testing.T) {
+	for i, example := range [][]byte{example1, example9, example13, example20, example21, example22} {
+		doc, err := ParseDocument(example)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c := doc.Clone(); !equalJSON(doc, c) {
+			t.Errorf("example %d: expected %s, got %s", i, doc, c)
+		}
+	}
+
+	doc, err := ParseDocument([]byte(`{
+		"@context": "https://www.w3.org/ns/did/v1",
+		"id": "did:example:123",
+		"controller": "did:example:456",
+		"verificationMethod": [{"id": "#key-1", "type": "JsonWebKey2020", "controller": "did:example:123", "publicKeyJwk": {"kty": "OKP"}, "revoked": false}],
+		"authentication": ["#key-1", {"id": "#key-2", "type": "Multikey", "controller": "did:example:123", "publicKeyMultibase": "z6Mk"}],
+		"service": [{"id": "#s", "type": "T", "serviceEndpoint": ["https://example.com", {"uri": "https://example.org"}]}],
+		"extension": {"value": 1}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.AssertionMethod = []IVerificationMethod{&customVerificationMethod{method: VerificationMethod{ID: "#key-3"}}}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := doc.Clone()
+	c.ID.MethodIDs[0] = "changed"
+	c.Controller[0].MethodIDs[0] = "changed"
+	c.VerificationMethod[0].PublicKeyJwk.Kty = "EC"
+	c.VerificationMethod[0].Extensions["revoked"][0] = 'F'
+	c.Authentication[0].(*RelativeVerificationMethod).RelativeURL = "#changed"
+	c.Authentication[1].(*VerificationMethod).ID = "#changed"
+	c.AssertionMethod[0].(*customVerificationMethod).method.ID = "#changed"
+	c.Service[0].Type[0] = "changed"
+	c.Service[0].ServiceEndpoint.Set[0].URI = "changed"
+	c.Service[0].ServiceEndpoint.Set[1].Map["uri"][1] = 'X'
+	c.Extensions["extension"][0] = '['
+
+	if r, _ := json.Marshal(doc); string(r) != string(raw) {
+		t.Errorf("the original was modified: %s", r)
+	}
+	if doc.AssertionMethod[0].(*customVerificationMethod).method.ID != "#key-3" {
+		t.Error("the custom verification method was not copied")
+	}
+}
+
+func TestDocument_View(t *testing.T) {
+	doc, err := ParseDocument(example21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := doc.View()
+	doc.VerificationMethod[0].ID = "#changed"
+	if _, ok := v.FindVerificationMethod("#keys-1"); !ok {
+		t.Error("the view was modified by the original")
+	}
+
+	methods, err := v.MethodsFor(Authentication)
+	if err != nil || len(methods) != 2 {
+		t.Fatal(methods, err)
+	}
+	methods[0].ID = "#changed"
+	v.VerificationMethods()[0].ID = "#changed"
+	v.Services()[0].ID = "#changed"
+	v.Context()[0].URL = "changed"
+	v.ID().MethodIDs[0] = "changed"
+	v.Document().Service = nil
+	if m, ok := v.FindVerificationMethod("#keys-1"); !ok || m.ID != "did:example:123#keys-1" {
+		t.Error(m)
+	}
+	if s, ok := v.FindService("#files"); !ok || s.ID != "#files" {
+		t.Error(s)
+	}
+	if v.Context()[0].URL != ContextV1 || v.ID().String() != "did:example:123" {
+		t.Error(v)
+	}
+	if r := v.RelationshipsOf("#keys-2"); len(r) != 1 || r[0] != Authentication {
+		t.Error(r)
+	}
+	if v.Validate().Err() != nil {
+		t.Error(v.Validate())
+	}
+}
+
+func TestDocumentView_zero(t *testing.T) {
+	var v DocumentView
+	if v.ID().Method != "" || v.Context() != nil || v.Controller() != nil || v.AlsoKnownAs() != nil {
+		t.Error(v)
+	}
+	if len(v.VerificationMethods()) != 0 || len(v.Services()) != 0 || v.RelationshipsOf("#key-1") != nil {
+		t.Error(v)
+	}
+	if _, ok := v.FindVerificationMethod("#key-1"); ok {
+		t.Error("found a verification method in the zero view")
+	}
+	if _, ok := v.FindService("#service"); ok {
+		t.Error("found a service in the zero view")
+	}
+	if _, ok := v.Extension("extension"); ok {
+		t.Error("found an extension in the zero view")
+	}
+	if methods, err := v.MethodsFor(Authentication); err != nil || len(methods) != 0 {
+		t.Error(methods, err)
+	}
+	if v.Document() == nil || v.Validate().Err() == nil {
+		t.Error("expected an invalid empty document")
+	}
+	_ = v.String()
+	if _, err := json.Marshal(v); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,73 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Context is an entry of the @context property: either the URL of a JSON-LD context, or an embedded context
+// definition.
+// DOCS: https://www.w3.org/TR/did-core/#json-ld
+type Context struct {
+	// URL is the URL of the context, empty if the context is embedded.
+	URL string
+	// Definition is the embedded context definition, e.g. {"@vocab": "https://example.com/vocab#"}.
+	Definition map[string]json.RawMessage
+}
+
+// NewContexts returns the contexts of the given URLs.
+func NewContexts(urls ...string) []Context {
+	contexts := make([]Context, len(urls))
+	for i, url := range urls {
+		contexts[i] = Context{URL: url}
+	}
+	return contexts
+}
+
+// contextURLs returns the URLs of the contexts, embedded contexts are skipped.
+func contextURLs(contexts []Context) []string {
+	var urls []string
+	for _, c := range contexts {
+		if c.URL != "" {
+			urls = append(urls, c.URL)
+		}
+	}
+	return urls
+}
+
+// Clone returns a deep copy of the context.
+func (c Context) Clone() Context {
+	return Context{URL: c.URL, Definition: cloneRawMessages(c.Definition)}
+}
+
+// IsEmbedded checks whether the context is an embedded context definition.
+func (c Context) IsEmbedded() bool {
+	return c.URL == ""
+}
+
+func (c Context) MarshalJSON() ([]byte, error) {
+	if !c.IsEmbedded() {
+		return json.Marshal(c.URL)
+	}
+	if c.Definition == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(c.Definition)
+}
+
+func (c *Context) UnmarshalJSON(raw []byte) error {
+	var url string
+	if err := json.Unmarshal(raw, &url); err == nil {
+		if url == "" {
+			return fmt.Errorf("invalid @context: empty URL")
+		}
+		*c = Context{URL: url}
+		return nil
+	}
+	var definition map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &definition); err != nil || definition == nil {
+		return fmt.Errorf("invalid @context: expected a URL or a context definition, got %s", raw)
+	}
+	*c = Context{Definition: definition}
+	return nil
+}
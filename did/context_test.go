@@ -0,0 +1,48 @@
+package did
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestContext_JSON(t *testing.T) {
+	for _, test := range []struct {
+		raw      string
+		contexts int
+		embedded int
+	}{
+		{`{"@context": "https://www.w3.org/ns/did/v1", "id": "did:example:123"}`, 1, 0},
+		{`{"@context": {"@vocab": "https://example.com/vocab#"}, "id": "did:example:123"}`, 1, 1},
+		{`{"@context": ["https://www.w3.org/ns/did/v1", {"@vocab": "https://example.com/vocab#"}], "id": "did:example:123"}`, 2, 1},
+	} {
+		doc, err := ParseDocument([]byte(test.raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		embedded := 0
+		for _, c := range doc.Context {
+			if c.IsEmbedded() {
+				embedded++
+			}
+		}
+		if len(doc.Context) != test.contexts || embedded != test.embedded {
+			t.Error(doc.Context)
+		}
+		raw, err := doc.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equalJSON(json.RawMessage(raw), json.RawMessage(test.raw)) {
+			t.Error(string(raw))
+		}
+	}
+	for _, raw := range []string{`{"@context": "", "id": "did:example:123"}`, `{"@context": [1], "id": "did:example:123"}`, `{"@context": [null], "id": "did:example:123"}`} {
+		if _, err := ParseDocument([]byte(raw)); err == nil {
+			t.Error("expected an error", raw)
+		}
+	}
+	var c Context
+	if err := json.Unmarshal([]byte(`"https://www.w3.org/ns/did/v1"`), &c); err != nil || c.IsEmbedded() {
+		t.Error(c, err)
+	}
+}
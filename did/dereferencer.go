@@ -0,0 +1,210 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/0x51-dev/did/internal/jsonutils"
+	"net/url"
+	"strings"
+)
+
+// Dereference dereferences the given DID URL to a resource.
+// DOCS: https://www.w3.org/TR/did-core/#did-url-dereferencing
+func (r DefaultResolver) Dereference(didURL string, options DereferencingOptions) DereferencingResult {
+	u, err := ParseDIDURL(didURL)
+	if err != nil {
+		return DereferencingResult{
+			Metadata: DereferencingMetadata{
+				Error:          InvalidDIDURLError,
+				ProblemDetails: NewProblemDetails(InvalidDIDURLError, err),
+			},
+		}
+	}
+	// The accepted media type is the one of the content stream, which is not necessarily a DID document, e.g. the
+	// service endpoints of a service. The document itself is resolved in its JSON representation.
+	resolution := r.Resolve(u.DID().String(), ResolutionOptions{Accept: MediaTypeJSON})
+	if resolution.Metadata.Error != "" {
+		return DereferencingResult{
+			Metadata: DereferencingMetadata{
+				Error:          resolution.Metadata.Error,
+				ProblemDetails: resolution.Metadata.ProblemDetails,
+			},
+		}
+	}
+	if resolution.Document == nil {
+		return DereferencingResult{
+			Metadata: DereferencingMetadata{
+				Error: NotFoundError,
+			},
+		}
+	}
+	result := dereference(*u, resolution)
+	if doc, ok := result.ContentStream.(*Document); ok {
+		switch options.Accept {
+		case MediaTypeJSONLD, MediaTypeCBOR:
+			if _, err := doc.MarshalRepresentation(options.Accept); err != nil {
+				return DereferencingResult{
+					Metadata: DereferencingMetadata{
+						Error:          RepresentationNotSupportedError,
+						ProblemDetails: NewProblemDetails(RepresentationNotSupportedError, err),
+					},
+				}
+			}
+			result.Metadata.ContentType = options.Accept
+		}
+	}
+	return result
+}
+
+func dereference(u DIDURL, resolution ResolutionResult) DereferencingResult {
+	if service, ok := u.Parameter(ServiceParameter); ok {
+		return dereferenceService(u, resolution.Document, service, u.RelativeRef())
+	}
+	if u.Path != "" {
+		return DereferencingResult{
+			Metadata: DereferencingMetadata{
+				Error: NotFoundError,
+			},
+		}
+	}
+
+	if u.Fragment == "" {
+		// The content metadata of a DID document are its document metadata.
+		metadata, err := documentMetadataObject(resolution.DocumentMetadata)
+		if err != nil {
+			return DereferencingResult{
+				Metadata: DereferencingMetadata{
+					Error:          InternalError,
+					ProblemDetails: NewProblemDetails(InternalError, err),
+				},
+			}
+		}
+		return DereferencingResult{
+			Metadata: DereferencingMetadata{
+				ContentType: resolution.Metadata.ContentType,
+			},
+			ContentStream:   resolution.Document,
+			ContentMetadata: metadata,
+		}
+	}
+
+	// Secondary resource, identified by the fragment.
+	if method := resolution.Document.FindVerificationMethod("#" + u.Fragment); method != nil {
+		return DereferencingResult{
+			Metadata: DereferencingMetadata{
+				ContentType: resolution.Metadata.ContentType,
+			},
+			ContentStream: method,
+		}
+	}
+	if service := resolution.Document.FindService("#" + u.Fragment); service != nil {
+		return DereferencingResult{
+			Metadata: DereferencingMetadata{
+				ContentType: resolution.Metadata.ContentType,
+			},
+			ContentStream: service,
+		}
+	}
+	return DereferencingResult{
+		Metadata: DereferencingMetadata{
+			Error: NotFoundError,
+		},
+	}
+}
+
+// documentMetadataObject returns the document metadata as JSON object.
+func documentMetadataObject(metadata DocumentMetadata) (map[string]any, error) {
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return jsonutils.DecodeObject(raw)
+}
+
+// dereferenceService selects the service endpoint of the service with the given ID, as described in the DID Resolution
+// specification.
+// DOCS: https://w3c-ccg.github.io/did-resolution/#dereferencing-algorithm-primary
+func dereferenceService(u DIDURL, document *Document, id, relativeRef string) DereferencingResult {
+	service := document.FindService("#" + id)
+	if service == nil {
+		return DereferencingResult{
+			Metadata: DereferencingMetadata{
+				Error: NotFoundError,
+			},
+		}
+	}
+	var endpoints []string
+	for _, endpoint := range service.ServiceEndpoint.URIs() {
+		if relativeRef != "" {
+			base, err := url.Parse(endpoint)
+			if err != nil {
+				continue
+			}
+			ref, err := url.Parse(relativeRef)
+			if err != nil {
+				return DereferencingResult{
+					Metadata: DereferencingMetadata{
+						Error: InvalidDIDURLError,
+					},
+				}
+			}
+			endpoint = base.ResolveReference(ref).String()
+		}
+		if u.Fragment != "" && !strings.Contains(endpoint, "#") {
+			endpoint = fmt.Sprintf("%s#%s", endpoint, u.Fragment)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	if len(endpoints) == 0 {
+		return DereferencingResult{
+			Metadata: DereferencingMetadata{
+				Error: NotFoundError,
+			},
+		}
+	}
+	return DereferencingResult{
+		Metadata: DereferencingMetadata{
+			ContentType: "text/uri-list",
+		},
+		ContentStream: strings.Join(endpoints, "\r\n"),
+	}
+}
+
+// DereferencingMetadata are metadata about the DID URL dereferencing process.
+// DOCS: https://www.w3.org/TR/did-core/#did-url-dereferencing-metadata
+type DereferencingMetadata struct {
+	// The Media Type of the returned contentStream.
+	ContentType string `json:"contentType,omitempty"`
+	// The error code from the dereferencing process.
+	// This property is REQUIRED when there is an error in the dereferencing process.
+	Error Error `json:"error,omitempty"`
+	// Details about the error, including its underlying cause.
+	ProblemDetails *ProblemDetails `json:"problemDetails,omitempty"`
+}
+
+// Err returns the error of the dereferencing process, or nil if there is none, see Metadata.Err.
+func (m DereferencingMetadata) Err() error {
+	return resolutionErr(m.Error, m.ProblemDetails)
+}
+
+// DereferencingOptions are options for dereferencing a DID URL.
+// DOCS: https://www.w3.org/TR/did-core/#did-url-dereferencing-options
+type DereferencingOptions struct {
+	// The Media Type that the caller prefers for the contentStream.
+	Accept string
+}
+
+type DereferencingResult struct {
+	Metadata DereferencingMetadata `json:"dereferencingMetadata"`
+	// The resource corresponding to the DID URL: a *Document, a *VerificationMethod, a *Service or a list of service
+	// endpoint URLs (string, text/uri-list). A *Document is returned in its abstract form, its representation is the one
+	// of the content type, see Document.MarshalRepresentation.
+	ContentStream any `json:"contentStream,omitempty"`
+	// Metadata about the contentStream.
+	ContentMetadata map[string]any `json:"contentMetadata"`
+}
+
+type Dereferenceable interface {
+	// Dereference returns the resource identified by the DID URL.
+	Dereference(didURL string, options DereferencingOptions) DereferencingResult
+}
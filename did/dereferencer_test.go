@@ -0,0 +1,162 @@
+package did
+
+import (
+	_ "embed"
+	"fmt"
+	"testing"
+)
+
+//go:embed testdata/example21.json
+var example21 []byte
+
+func exampleResolver(t *testing.T) DefaultResolver {
+	return DefaultResolver{
+		Registry: Registry{
+			"example": func(didURL string, did DID, _ Resolvable, _ ResolutionOptions) ResolutionResult {
+				if did.String() != "did:example:123" {
+					return ResolutionResult{Metadata: Metadata{Error: NotFoundError}}
+				}
+				doc, err := ParseDocument(example21)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return ResolutionResult{
+					Metadata:         Metadata{ContentType: "application/did+json"},
+					Document:         doc,
+					DocumentMetadata: DocumentMetadata{VersionID: "1"},
+				}
+			},
+		},
+	}
+}
+
+func TestDefaultResolver_Dereference(t *testing.T) {
+	r := exampleResolver(t)
+	for _, test := range []struct {
+		didURL string
+		id     string
+	}{
+		{"did:example:123#keys-1", "did:example:123#keys-1"},
+		{"did:example:123#keys-2", "#keys-2"},
+		{"did:example:123#files", "#files"},
+		{"did:example:123#agent", "did:example:123#agent"},
+	} {
+		t.Run(test.didURL, func(t *testing.T) {
+			result := r.Dereference(test.didURL, DereferencingOptions{})
+			if result.Metadata.Error != "" {
+				t.Fatal(result.Metadata.Error)
+			}
+			switch resource := result.ContentStream.(type) {
+			case *VerificationMethod:
+				if resource.ID != test.id {
+					t.Error(resource.ID, test.id)
+				}
+			case *Service:
+				if resource.ID != test.id {
+					t.Error(resource.ID, test.id)
+				}
+			default:
+				t.Errorf("unexpected resource: %T", resource)
+			}
+		})
+	}
+}
+
+func TestDefaultResolver_Dereference_errors(t *testing.T) {
+	r := exampleResolver(t)
+	for _, test := range []struct {
+		didURL string
+		err    Error
+	}{
+		{"did:example", InvalidDIDURLError},
+		{"did:other:123", MethodNotSupportedError},
+		{"did:example:456", NotFoundError},
+		{"did:example:123#keys-3", NotFoundError},
+		{"did:example:123?service=unknown", NotFoundError},
+		{"did:example:123/some/path", NotFoundError},
+	} {
+		t.Run(test.didURL, func(t *testing.T) {
+			if result := r.Dereference(test.didURL, DereferencingOptions{}); result.Metadata.Error != test.err {
+				t.Error(result.Metadata.Error, test.err)
+			}
+		})
+	}
+}
+
+func TestDefaultResolver_Dereference_primary(t *testing.T) {
+	r := exampleResolver(t)
+	result := r.Dereference("did:example:123", DereferencingOptions{})
+	if result.Metadata.Error != "" {
+		t.Fatal(result.Metadata.Error)
+	}
+	if doc, ok := result.ContentStream.(*Document); !ok || doc.ID.String() != "did:example:123" {
+		t.Errorf("unexpected resource: %v", result.ContentStream)
+	}
+	if result.Metadata.ContentType != "application/did+json" {
+		t.Error(result.Metadata.ContentType)
+	}
+	if result.ContentMetadata["versionId"] != "1" {
+		t.Error(result.ContentMetadata)
+	}
+}
+
+func TestDefaultResolver_Dereference_accept(t *testing.T) {
+	r := exampleResolver(t)
+	resolve := r.Registry["example"]
+	r.Registry["example"] = func(didURL string, did DID, resolver Resolvable, options ResolutionOptions) ResolutionResult {
+		// Like did:web, the method only supports the JSON representation.
+		if options.Accept != MediaTypeJSON {
+			return NewErrorResult(RepresentationNotSupportedError, fmt.Errorf("unsupported representation: %q", options.Accept))
+		}
+		return resolve(didURL, did, resolver, options)
+	}
+	for _, test := range []struct {
+		didURL      string
+		accept      string
+		contentType string
+	}{
+		{"did:example:123", "", MediaTypeJSON},
+		{"did:example:123", MediaTypeJSONLD, MediaTypeJSONLD},
+		{"did:example:123", MediaTypeCBOR, MediaTypeCBOR},
+		{"did:example:123#keys-1", "", MediaTypeJSON},
+		{"did:example:123?service=agent", "", "text/uri-list"},
+		{"did:example:123?service=agent", "text/uri-list", "text/uri-list"},
+	} {
+		t.Run(test.didURL+" "+test.accept, func(t *testing.T) {
+			result := r.Dereference(test.didURL, DereferencingOptions{Accept: test.accept})
+			if err := result.Metadata.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if result.Metadata.ContentType != test.contentType {
+				t.Error(result.Metadata.ContentType, test.contentType)
+			}
+		})
+	}
+}
+
+func TestDefaultResolver_Dereference_service(t *testing.T) {
+	r := exampleResolver(t)
+	for _, test := range []struct {
+		didURL   string
+		endpoint string
+	}{
+		{"did:example:123?service=agent", "https://agent.example.com"},
+		{"did:example:123;service=agent", "https://agent.example.com"},
+		{"did:example:123?service=files&relativeRef=/resume.pdf", "https://example.com/resume.pdf"},
+		{"did:example:123?service=files&relativeRef=%2Fresume.pdf", "https://example.com/resume.pdf"},
+		{"did:example:123?service=files&relativeRef=resume.pdf#page", "https://example.com/files/resume.pdf#page"},
+	} {
+		t.Run(test.didURL, func(t *testing.T) {
+			result := r.Dereference(test.didURL, DereferencingOptions{})
+			if result.Metadata.Error != "" {
+				t.Fatal(result.Metadata.Error)
+			}
+			if result.Metadata.ContentType != "text/uri-list" {
+				t.Error(result.Metadata.ContentType)
+			}
+			if result.ContentStream != test.endpoint {
+				t.Error(result.ContentStream, test.endpoint)
+			}
+		})
+	}
+}
@@ -6,6 +6,15 @@ import (
 	"strings"
 )
 
+func getParameter(parameters []Parameter, key string) (string, bool) {
+	for _, p := range parameters {
+		if p.Key == key {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
 func isHexadecimal(c byte) bool {
 	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
 }
@@ -22,7 +31,127 @@ func isParameterCharacter(c byte) bool {
 	return isIDCharacter(c) || c == ':' || c == '%'
 }
 
+// isParameterNameCharacter checks whether the character is a param-char, excluding the percent sign.
+func isParameterNameCharacter(c byte) bool {
+	return isIDCharacter(c) || c == ':'
+}
+
+func isSubDelimiter(c byte) bool {
+	return strings.IndexByte("!$&'()*+,;=", c) != -1
+}
+
+func newParseError(input string, offset int, production string, format string, a ...any) *ParseError {
+	return &ParseError{
+		Input:      input,
+		Offset:     offset,
+		Production: production,
+		Reason:     fmt.Sprintf(format, a...),
+	}
+}
+
+// parseParameterCharacters parses a (possibly empty) sequence of param-char, returning the offset of the first character
+// that is not a param-char.
+func parseParameterCharacters(didURL string, i int) (int, error) {
+	for ; i < len(didURL); i++ {
+		if !isParameterCharacter(didURL[i]) {
+			break
+		}
+		if didURL[i] == '%' {
+			if err := parsePercentEncoding(didURL, i); err != nil {
+				return i, err
+			}
+			i += 2
+		}
+	}
+	return i, nil
+}
+
+// parsePathCharacter checks whether the character at the given offset is a pchar or one of the additional characters.
+// DOCS: https://www.rfc-editor.org/rfc/rfc3986#section-3.3
+func parsePathCharacter(didURL string, i int, production string, additional string) error {
+	c := didURL[i]
+	if c == '%' {
+		return parsePercentEncoding(didURL, i)
+	}
+	if isUnreserved(c) || isSubDelimiter(c) || c == ':' || c == '@' || strings.IndexByte(additional, c) != -1 {
+		return nil
+	}
+	return newParseError(didURL, i, production, "invalid character %q", c)
+}
+
+func parsePercentEncoding(didURL string, i int) error {
+	if len(didURL) <= i+2 || !isHexadecimal(didURL[i+1]) || !isHexadecimal(didURL[i+2]) {
+		return newParseError(didURL, i, "pct-encoded", "invalid percent-encoding")
+	}
+	return nil
+}
+
+// DID is a decentralized identifier, without any of the additional DID URL components.
+// DOCS: https://www.w3.org/TR/did-core/#did-syntax
 type DID struct {
+	Method    string
+	MethodIDs []string
+}
+
+// ParseDID parses a DID URL, of which DID returns the DID part.
+//
+// Deprecated: ParseDID accepts DID URLs for backwards compatibility, use ParseDIDURL to parse DID URLs and ParseBareDID
+// to parse DIDs without any of the additional DID URL components.
+func ParseDID(didURL string) (*DIDURL, error) {
+	return ParseDIDURL(didURL)
+}
+
+// ParseBareDID parses a DID. DID URLs, with parameters, a path, query or fragment, are rejected. The method-specific
+// identifier is validated with the validator registered for its method, see RegisterValidator.
+func ParseBareDID(did string) (*DID, error) {
+	u, err := ParseDIDURL(did)
+	if err != nil {
+		return nil, err
+	}
+	d := u.DID()
+	if n := len(d.String()); n != len(did) {
+		return nil, newParseError(did, n, "did", "unexpected DID URL component %q", did[n])
+	}
+	return &d, nil
+}
+
+func (d DID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d DID) String() string {
+	var sb strings.Builder
+	sb.WriteString("did:")
+	sb.WriteString(d.Method)
+	sb.WriteString(":")
+	for i, id := range d.MethodIDs {
+		if i != 0 {
+			sb.WriteString(":")
+		}
+		sb.WriteString(id)
+	}
+	return sb.String()
+}
+
+// URL returns the DID as a DID URL.
+func (d DID) URL() DIDURL {
+	return DIDURL{
+		Method:    d.Method,
+		MethodIDs: d.MethodIDs,
+	}
+}
+
+func (d *DID) UnmarshalJSON(raw []byte) error {
+	var did string
+	if err := json.Unmarshal(raw, &did); err != nil {
+		return fmt.Errorf("invalid DID: %s", raw)
+	}
+	return d.Set(did)
+}
+
+// DIDURL is a DID URL: a DID with optional parameters, path, query and fragment.
+// DOCS: https://www.w3.org/TR/did-core/#did-url-syntax
+type DIDURL struct {
 	Method     string
 	MethodIDs  []string
 	Parameters []Parameter
@@ -31,13 +160,11 @@ type DID struct {
 	Fragment   string
 }
 
-func ParseDID(didURL string) (*DID, error) {
-	if didURL == "" {
-		return nil, fmt.Errorf("invalid DID: %s", didURL)
-	}
-
+// ParseDIDURL parses a DID URL. The method-specific identifier is validated with the validator registered for its
+// method, see RegisterValidator.
+func ParseDIDURL(didURL string) (*DIDURL, error) {
 	if !strings.HasPrefix(didURL, "did:") {
-		return nil, fmt.Errorf("invalid DID: %s", didURL)
+		return nil, &ParseError{Input: didURL, Production: "did", Reason: `missing "did:" scheme`}
 	}
 
 	var i = 4
@@ -47,9 +174,17 @@ func ParseDID(didURL string) (*DID, error) {
 		}
 	}
 	var method = didURL[4:i]
-
-	if len(didURL) <= i || didURL[i] != ':' {
-		return nil, fmt.Errorf("invalid DID: %s", didURL)
+	if method == "" {
+		if i < len(didURL) && didURL[i] != ':' {
+			return nil, newParseError(didURL, i, "method-char", "invalid character %q", didURL[i])
+		}
+		return nil, newParseError(didURL, i, "method-name", "empty method name")
+	}
+	if len(didURL) <= i {
+		return nil, newParseError(didURL, i, "did", `missing ":" after method name`)
+	}
+	if didURL[i] != ':' {
+		return nil, newParseError(didURL, i, "method-char", "invalid character %q", didURL[i])
 	}
 	i++
 
@@ -62,9 +197,8 @@ func ParseDID(didURL string) (*DID, error) {
 			continue
 		}
 		if didURL[i] == '%' {
-			// Percent-encoded character.
-			if len(didURL) <= i+2 || !isHexadecimal(didURL[i+1]) || !isHexadecimal(didURL[i+2]) {
-				return nil, fmt.Errorf("invalid DID: %s", didURL)
+			if err := parsePercentEncoding(didURL, i); err != nil {
+				return nil, err
 			}
 			i += 2
 		}
@@ -73,39 +207,42 @@ func ParseDID(didURL string) (*DID, error) {
 		}
 	}
 	if i == j {
-		return nil, fmt.Errorf("invalid DID: %s", didURL)
+		return nil, newParseError(didURL, i, "method-specific-id", "expected idchar")
 	}
 	methodIDs = append(methodIDs, didURL[j:i])
 
+	// The production that is being parsed, used to report unexpected characters.
+	var production = "idchar"
+
 	var parameters []Parameter
 	for i < len(didURL) {
 		if didURL[i] != ';' {
 			break
 		}
 		i++
-		for j = i; i < len(didURL); i++ {
-			if !isParameterCharacter(didURL[i]) {
-				break
-			}
+		j = i
+		var err error
+		if i, err = parseParameterCharacters(didURL, i); err != nil {
+			return nil, err
 		}
 		if i == j {
-			return nil, fmt.Errorf("invalid DID: %s", didURL)
+			return nil, newParseError(didURL, i, "param-name", "empty parameter name")
 		}
 		var k = didURL[j:i]
 		if len(didURL) <= i || didURL[i] != '=' {
-			return nil, fmt.Errorf("invalid DID: %s", didURL)
+			return nil, newParseError(didURL, i, "param", `expected "=" after parameter name`)
 		}
 		i++
-		for j = i; i < len(didURL); i++ {
-			if !isParameterCharacter(didURL[i]) {
-				break
-			}
+		j = i
+		if i, err = parseParameterCharacters(didURL, i); err != nil {
+			return nil, err
 		}
 		var v = didURL[j:i]
 		parameters = append(parameters, Parameter{
 			Key:   k,
 			Value: v,
 		})
+		production = "param-char"
 	}
 
 	var path string
@@ -115,32 +252,58 @@ func ParseDID(didURL string) (*DID, error) {
 			if c := didURL[i]; c == '?' || c == '#' {
 				break
 			}
+			if err := parsePathCharacter(didURL, i, "path", "/"); err != nil {
+				return nil, err
+			}
 		}
 		path = didURL[j:i]
 	}
 
 	var query string
 	if i < len(didURL) && didURL[i] == '?' {
-		j = i + 1
+		i++
+		j = i
 		for ; i < len(didURL); i++ {
 			if c := didURL[i]; c == '#' {
 				break
 			}
+			if err := parsePathCharacter(didURL, i, "query", "/?"); err != nil {
+				return nil, err
+			}
 		}
 		query = didURL[j:i]
 	}
 
 	var fragment string
 	if i < len(didURL) && didURL[i] == '#' {
-		fragment = didURL[i+1:]
-		i = len(didURL)
+		i++
+		j = i
+		for ; i < len(didURL); i++ {
+			if err := parsePathCharacter(didURL, i, "fragment", "/?"); err != nil {
+				return nil, err
+			}
+		}
+		fragment = didURL[j:i]
 	}
 
 	if i != len(didURL) {
-		return nil, fmt.Errorf("invalid DID: %s", didURL)
+		return nil, newParseError(didURL, i, production, "invalid character %q", didURL[i])
 	}
 
-	return &DID{
+	did := DID{
+		Method:    method,
+		MethodIDs: methodIDs,
+	}
+	if err := validateDefault(did); err != nil {
+		return nil, &ParseError{
+			Input:      didURL,
+			Offset:     len(method) + 5,
+			Production: "method-specific-id",
+			Reason:     err.Error(),
+			Err:        err,
+		}
+	}
+	return &DIDURL{
 		Method:     method,
 		MethodIDs:  methodIDs,
 		Parameters: parameters,
@@ -150,35 +313,26 @@ func ParseDID(didURL string) (*DID, error) {
 	}, nil
 }
 
-func (d *DID) DID() string {
-	var sb strings.Builder
-	sb.WriteString("did:")
-	sb.WriteString(d.Method)
-	sb.WriteString(":")
-	for i, id := range d.MethodIDs {
-		if i != 0 {
-			sb.WriteString(":")
-		}
-		sb.WriteString(id)
+// DID returns the DID of the DID URL, without any of the additional DID URL components.
+func (d DIDURL) DID() DID {
+	return DID{
+		Method:    d.Method,
+		MethodIDs: d.MethodIDs,
 	}
-	return sb.String()
 }
 
-func (d DID) MarshalJSON() ([]byte, error) {
+// IsDID checks whether the DID URL is a plain DID, i.e. whether it has no parameters, path, query or fragment.
+func (d DIDURL) IsDID() bool {
+	return len(d.Parameters) == 0 && d.Path == "" && d.Query == "" && d.Fragment == ""
+}
+
+func (d DIDURL) MarshalJSON() ([]byte, error) {
 	return json.Marshal(d.String())
 }
 
-func (d *DID) String() string {
+func (d DIDURL) String() string {
 	var sb strings.Builder
-	sb.WriteString("did:")
-	sb.WriteString(d.Method)
-	sb.WriteString(":")
-	for i, id := range d.MethodIDs {
-		if i != 0 {
-			sb.WriteString(":")
-		}
-		sb.WriteString(id)
-	}
+	sb.WriteString(d.DID().String())
 	for _, p := range d.Parameters {
 		sb.WriteString(";")
 		sb.WriteString(p.Key)
@@ -199,18 +353,12 @@ func (d *DID) String() string {
 	return sb.String()
 }
 
-func (d *DID) UnmarshalJSON(raw []byte) error {
-	didURL := string(raw)
-	if !strings.HasPrefix(didURL, `"`) || !strings.HasSuffix(didURL, `"`) {
-		return fmt.Errorf("invalid DID: %s", didURL)
-	}
-	didURL = didURL[1 : len(didURL)-1]
-	u, err := ParseDID(didURL)
-	if err != nil {
-		return err
+func (d *DIDURL) UnmarshalJSON(raw []byte) error {
+	var didURL string
+	if err := json.Unmarshal(raw, &didURL); err != nil {
+		return fmt.Errorf("invalid DID URL: %s", raw)
 	}
-	*d = *u
-	return nil
+	return d.Set(didURL)
 }
 
 // Parameter is a DID parameter.
@@ -218,4 +366,28 @@ func (d *DID) UnmarshalJSON(raw []byte) error {
 type Parameter struct {
 	Key   string
 	Value string
+	// NoValue is set for query parameters without a value, e.g. "?a" rather than "?a=", so they are encoded as is.
+	NoValue bool
+}
+
+// ParseError is returned by ParseBareDID and ParseDIDURL if the input does not conform to the DID (URL) syntax.
+type ParseError struct {
+	// The input that was parsed.
+	Input string
+	// The byte offset in the input at which the error occurred.
+	Offset int
+	// The (ABNF) production that failed, e.g. "method-name", "idchar", "pct-encoded", "param-char" or "path".
+	Production string
+	// A human-readable reason.
+	Reason string
+	// The underlying error, e.g. returned by a method-specific Validator.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid DID: %s: %s at offset %d (%s)", e.Input, e.Reason, e.Offset, e.Production)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
 }
@@ -1,6 +1,7 @@
 package did_test
 
 import (
+	"errors"
 	"fmt"
 	"github.com/0x51-dev/did/did"
 	"testing"
@@ -15,6 +16,35 @@ func ExampleDID() {
 	// did:example:test:21tDAKCERh95uGgKbJNHYp;service=agent;foo:bar=high/some/path?foo=bar#key1
 }
 
+func TestParseBareDID(t *testing.T) {
+	for _, test := range []string{
+		"did:example:123456789abcdefghi",
+		"did:web:localhost%3A8443:user:alice",
+	} {
+		d, err := did.ParseBareDID(test)
+		if err != nil {
+			t.Fatalf("ParseBareDID(%q): %v", test, err)
+		}
+		if s := d.String(); s != test {
+			t.Error(s, test)
+		}
+	}
+
+	for _, test := range []string{
+		"did:example:123/path",
+		"did:example:123;service=agent",
+		"did:example:123?",
+		"did:example:123#key-1",
+	} {
+		var parseErr *did.ParseError
+		if _, err := did.ParseBareDID(test); !errors.As(err, &parseErr) {
+			t.Errorf("ParseBareDID(%q): expected a ParseError, got %v", test, err)
+		} else if parseErr.Offset != 15 {
+			t.Error(parseErr.Offset)
+		}
+	}
+}
+
 func TestParseDID_examples(t *testing.T) {
 	// SOURCE: https://www.w3.org/TR/did-core/#did-url-syntax
 	for i, test := range []string{
@@ -33,3 +63,45 @@ func TestParseDID_examples(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDIDURL_errors(t *testing.T) {
+	for _, test := range []struct {
+		didURL     string
+		offset     int
+		production string
+	}{
+		{"", 0, "did"},
+		{"urn:example:123", 0, "did"},
+		{"did::123", 4, "method-name"},
+		{"did:Example:123", 4, "method-char"},
+		{"did:exAmple:123", 6, "method-char"},
+		{"did:example", 11, "did"},
+		{"did:example:", 12, "method-specific-id"},
+		{"did:example:123:", 16, "method-specific-id"},
+		{"did:example:12%3", 14, "pct-encoded"},
+		{"did:example:12%G0", 14, "pct-encoded"},
+		{"did:example:123!", 15, "idchar"},
+		{"did:example:123;=1", 16, "param-name"},
+		{"did:example:123;a", 17, "param"},
+		{"did:example:123;a=%1", 18, "pct-encoded"},
+		{"did:example:123;a=1!", 19, "param-char"},
+		{"did:example:123/a b", 17, "path"},
+		{"did:example:123?a=<b>", 18, "query"},
+		{"did:example:123#key 1", 19, "fragment"},
+		{"did:example:123#key#1", 19, "fragment"},
+	} {
+		t.Run(test.didURL, func(t *testing.T) {
+			_, err := did.ParseDIDURL(test.didURL)
+			var parseErr *did.ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected a ParseError, got %v", err)
+			}
+			if parseErr.Offset != test.offset {
+				t.Error(parseErr.Offset, test.offset)
+			}
+			if parseErr.Production != test.production {
+				t.Error(parseErr.Production, test.production)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package did
+
+import (
+	"encoding/json"
+	"github.com/0x51-dev/did/internal/jsonpatch"
+	"github.com/0x51-dev/did/internal/jsonutils"
+	"reflect"
+	"sort"
+)
+
+// JSONPatch is an RFC 6902 JSON Patch document.
+// DOCS: https://www.rfc-editor.org/rfc/rfc6902
+type JSONPatch = jsonpatch.Patch
+
+// JSONPatchOperation is a single operation of a JSONPatch.
+type JSONPatchOperation = jsonpatch.Operation
+
+// ChangeKind is the kind of semantic change between two versions of a DID document.
+type ChangeKind string
+
+const (
+	// MethodAdded - a verification method was added.
+	MethodAdded ChangeKind = "methodAdded"
+	// MethodRemoved - a verification method was removed.
+	MethodRemoved ChangeKind = "methodRemoved"
+	// MethodRotated - the verification material of a verification method changed, i.e. the key was rotated.
+	MethodRotated ChangeKind = "methodRotated"
+	// MethodModified - other properties of a verification method changed, e.g. its type or controller.
+	MethodModified ChangeKind = "methodModified"
+	// RelationshipAdded - a verification method was added to a verification relationship.
+	RelationshipAdded ChangeKind = "relationshipAdded"
+	// RelationshipRemoved - a verification method was removed from a verification relationship.
+	RelationshipRemoved ChangeKind = "relationshipRemoved"
+	// ServiceAdded - a service was added.
+	ServiceAdded ChangeKind = "serviceAdded"
+	// ServiceRemoved - a service was removed.
+	ServiceRemoved ChangeKind = "serviceRemoved"
+	// ServiceModified - the type, endpoint or other properties of a service changed.
+	ServiceModified ChangeKind = "serviceModified"
+	// PropertyModified - any other property of the document was added, removed or changed, e.g. the controller.
+	PropertyModified ChangeKind = "propertyModified"
+)
+
+// Change is a semantic change between two versions of a DID document.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// The absolute ID of the verification method or service.
+	ID string `json:"id,omitempty"`
+	// The verification relationship, for RelationshipAdded and RelationshipRemoved.
+	Relationship Relationship `json:"relationship,omitempty"`
+	// The name of the property, for PropertyModified.
+	Property string `json:"property,omitempty"`
+}
+
+// Diff returns the semantic changes between two versions of the document. Identifiers are compared in their
+// absolute, normal form: relative and absolute references to the same method are equal.
+func Diff(from, to *Document) ([]Change, error) {
+	a, err := from.Absolute()
+	if err != nil {
+		return nil, err
+	}
+	b, err := to.Absolute()
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+
+	// Verification methods.
+	oldMethods, oldIDs := a.embeddedMethods()
+	newMethods, newIDs := b.embeddedMethods()
+	for _, id := range oldIDs {
+		o := oldMethods[id]
+		n, ok := newMethods[id]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: MethodRemoved, ID: o.ID})
+		case !o.sameMaterial(&n):
+			changes = append(changes, Change{Kind: MethodRotated, ID: o.ID})
+		case !equalJSON(&o, &n):
+			changes = append(changes, Change{Kind: MethodModified, ID: o.ID})
+		}
+	}
+	for _, id := range newIDs {
+		if _, ok := oldMethods[id]; !ok {
+			changes = append(changes, Change{Kind: MethodAdded, ID: newMethods[id].ID})
+		}
+	}
+
+	// Verification relationships.
+	for _, r := range Relationships {
+		oldRefs, oldIDs := a.relationshipReferences(r)
+		newRefs, newIDs := b.relationshipReferences(r)
+		for _, id := range oldIDs {
+			if _, ok := newRefs[id]; !ok {
+				changes = append(changes, Change{Kind: RelationshipRemoved, ID: oldRefs[id], Relationship: r})
+			}
+		}
+		for _, id := range newIDs {
+			if _, ok := oldRefs[id]; !ok {
+				changes = append(changes, Change{Kind: RelationshipAdded, ID: newRefs[id], Relationship: r})
+			}
+		}
+	}
+
+	// Services.
+	for _, o := range a.Service {
+		n := b.FindService(o.ID)
+		switch {
+		case n == nil:
+			changes = append(changes, Change{Kind: ServiceRemoved, ID: o.ID})
+		case !equalJSON(&o, n):
+			changes = append(changes, Change{Kind: ServiceModified, ID: o.ID})
+		}
+	}
+	for _, n := range b.Service {
+		if a.FindService(n.ID) == nil {
+			changes = append(changes, Change{Kind: ServiceAdded, ID: n.ID})
+		}
+	}
+
+	// Other properties.
+	oldProperties, err := documentProperties(a)
+	if err != nil {
+		return nil, err
+	}
+	newProperties, err := documentProperties(b)
+	if err != nil {
+		return nil, err
+	}
+	var properties []string
+	for k, v := range oldProperties {
+		if w, ok := newProperties[k]; !ok || !reflect.DeepEqual(v, w) {
+			properties = append(properties, k)
+		}
+	}
+	for k := range newProperties {
+		if _, ok := oldProperties[k]; !ok {
+			properties = append(properties, k)
+		}
+	}
+	sort.Strings(properties)
+	for _, k := range properties {
+		changes = append(changes, Change{Kind: PropertyModified, Property: k})
+	}
+	return changes, nil
+}
+
+// CreatePatch returns the JSON Patch that transforms the JSON representation of one version of the document into the
+// other.
+func CreatePatch(from, to *Document) (JSONPatch, error) {
+	a, err := documentObject(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := documentObject(to)
+	if err != nil {
+		return nil, err
+	}
+	return jsonpatch.Diff(a, b)
+}
+
+// ApplyPatch applies the JSON Patch to the JSON representation of the document and returns the resulting document. The
+// result must conform to the DID specification, see Document.Validate. The document itself is not modified.
+func (d *Document) ApplyPatch(patch JSONPatch) (*Document, error) {
+	m, err := documentObject(d)
+	if err != nil {
+		return nil, err
+	}
+	v, err := jsonpatch.Apply(m, patch)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDocument(raw, ParseOptions{Strict: true})
+}
+
+// documentObject returns the JSON representation of the document as a map.
+func documentObject(d *Document) (map[string]any, error) {
+	raw, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return jsonutils.DecodeObject(raw)
+}
+
+// documentProperties returns the JSON representation of all properties of the document, except for the verification
+// methods, relationships and services.
+func documentProperties(d *Document) (map[string]any, error) {
+	m, err := documentObject(d)
+	if err != nil {
+		return nil, err
+	}
+	delete(m, "verificationMethod")
+	delete(m, "service")
+	for _, r := range Relationships {
+		delete(m, string(r))
+	}
+	return m, nil
+}
+
+// embeddedMethods returns all verification methods of the (absolute) document by their normalized ID, and the IDs in
+// the order of the document.
+func (d *Document) embeddedMethods() (map[string]VerificationMethod, []string) {
+	methods := make(map[string]VerificationMethod)
+	var ids []string
+	add := func(method VerificationMethod) {
+		id := method.ID
+		if u, err := ParseDIDURL(id); err == nil {
+			id = u.Normalize().String()
+		}
+		if _, ok := methods[id]; !ok {
+			methods[id] = method
+			ids = append(ids, id)
+		}
+	}
+	for _, method := range d.VerificationMethod {
+		add(method)
+	}
+	for _, r := range Relationships {
+		for _, method := range *d.relationship(r) {
+			if method, ok := method.(*VerificationMethod); ok {
+				add(*method)
+			}
+		}
+	}
+	return methods, ids
+}
+
+// relationshipReferences returns the IDs of all verification methods of the (absolute) document in the given
+// relationship by their normalized ID, and the normalized IDs in the order of the document.
+func (d *Document) relationshipReferences(r Relationship) (map[string]string, []string) {
+	refs := make(map[string]string)
+	var ids []string
+	for _, method := range *d.relationship(r) {
+		var id string
+		switch method := method.(type) {
+		case *VerificationMethod:
+			id = method.ID
+		case *RelativeVerificationMethod:
+			id = method.RelativeURL
+		default:
+			continue
+		}
+		key := id
+		if u, err := ParseDIDURL(id); err == nil {
+			key = u.Normalize().String()
+		}
+		if _, ok := refs[key]; !ok {
+			refs[key] = id
+			ids = append(ids, key)
+		}
+	}
+	return refs, ids
+}
+
+// equalJSON checks whether both values have the same JSON representation, regardless of the order of the properties.
+func equalJSON(a, b any) bool {
+	var values [2]any
+	for i, v := range []any{a, b} {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return false
+		}
+		if values[i], err = jsonutils.Decode(raw); err != nil {
+			return false
+		}
+	}
+	return reflect.DeepEqual(values[0], values[1])
+}
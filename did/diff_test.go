@@ -0,0 +1,109 @@
+package did
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	from, err := ParseDocument(example21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := ParseDocument([]byte(`{
+		"@context": ["https://www.w3.org/ns/did/v1", "https://w3id.org/security/suites/ed25519-2020/v1"],
+		"id": "did:example:123",
+		"controller": "did:example:456",
+		"verificationMethod": [
+			{"id": "#keys-1", "type": "Ed25519VerificationKey2020", "controller": "did:example:123", "publicKeyMultibase": "z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"},
+			{"id": "#keys-3", "type": "Ed25519VerificationKey2020", "controller": "did:example:123", "publicKeyMultibase": "zH3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"}
+		],
+		"authentication": ["did:example:123#keys-1"],
+		"assertionMethod": ["#keys-3"],
+		"service": [
+			{"id": "#files", "type": "LinkedDomains", "serviceEndpoint": "https://example.com/files/"},
+			{"id": "#agent", "type": "DIDCommMessaging", "serviceEndpoint": "https://agent.example.org"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes, err := Diff(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Change{
+		{Kind: MethodRotated, ID: "did:example:123#keys-1"},
+		{Kind: MethodRemoved, ID: "did:example:123#keys-2"},
+		{Kind: MethodAdded, ID: "did:example:123#keys-3"},
+		{Kind: RelationshipRemoved, ID: "did:example:123#keys-2", Relationship: Authentication},
+		{Kind: RelationshipAdded, ID: "did:example:123#keys-3", Relationship: AssertionMethod},
+		{Kind: ServiceModified, ID: "did:example:123#agent"},
+		{Kind: PropertyModified, Property: "controller"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %v, got %v", expected, changes)
+	}
+
+	if changes, err := Diff(from, from); err != nil || len(changes) != 0 {
+		t.Error(changes, err)
+	}
+}
+
+func TestDocument_ApplyPatch(t *testing.T) {
+	from, err := ParseDocument(example21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := ParseDocument(example22)
+	if err != nil {
+		t.Fatal(err)
+	}
+	patch, err := CreatePatch(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := from.ApplyPatch(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalJSON(doc, to) {
+		t.Errorf("expected %s, got %s", to, doc)
+	}
+	if changes, err := Diff(doc, to); err != nil || len(changes) != 0 {
+		t.Error(changes, err)
+	}
+
+	// The patch is serialized as a JSON Patch document.
+	raw, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p JSONPatch
+	if err := json.Unmarshal(raw, &p); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p, patch) {
+		t.Error(string(raw))
+	}
+}
+
+func TestDocument_ApplyPatch_invalid(t *testing.T) {
+	doc, err := ParseDocument(example21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := doc.ApplyPatch(JSONPatch{{Op: "remove", Path: "/verificationMethod/1"}}); err == nil {
+		t.Error("expected an error")
+	}
+	_, err = doc.ApplyPatch(JSONPatch{{Op: "remove", Path: "/verificationMethod/0"}})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Error(err)
+	}
+	if len(doc.VerificationMethod) != 1 {
+		t.Error("the document was modified")
+	}
+}
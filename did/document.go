@@ -8,7 +8,7 @@ import (
 // Document is a DID document.
 // DOCS: https://www.w3.org/TR/did-core/#data-model
 type Document struct {
-	Context            []string            `json:"@context,omitempty"`
+	Context            []Context           `json:"@context,omitempty"`
 	ID                 DID                 `json:"id"`
 	AlsoKnownAs        []string            `json:"alsoKnownAs,omitempty"`
 	Controller         []DID               `json:"controller,omitempty"`
@@ -21,13 +21,18 @@ type Document struct {
 	CapabilityDelegation []IVerificationMethod `json:"capabilityDelegation,omitempty"`
 	// Services
 	Service []Service `json:"service,omitempty"`
+	// Extensions are all other properties, e.g. registered in the DID specification registries or method-specific.
+	Extensions map[string]json.RawMessage `json:"-"`
 }
 
-func ParseDocument(raw []byte) (*Document, error) {
+func ParseDocument(raw []byte, options ...ParseOptions) (*Document, error) {
 	var doc Document
 	if err := json.Unmarshal(raw, &doc); err != nil {
 		return nil, err
 	}
+	if err := doc.validateParsed(options); err != nil {
+		return nil, err
+	}
 	return &doc, nil
 }
 
@@ -36,8 +41,8 @@ func (d *Document) MarshalJSON() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	var m map[string]any
-	if err := json.Unmarshal(raw, &m); err != nil {
+	m, err := jsonutils.DecodeObject(raw)
+	if err != nil {
 		return nil, err
 	}
 	if err := jsonutils.FlattenStringOrSetOrMap(m, []jsonutils.FlattenField{
@@ -46,6 +51,7 @@ func (d *Document) MarshalJSON() ([]byte, error) {
 	}); err != nil {
 		return nil, err
 	}
+	jsonutils.MergeExtensions(m, d.Extensions)
 	return json.Marshal(m)
 }
 
@@ -70,7 +76,7 @@ func (d *Document) UnmarshalJSON(raw []byte) error {
 	}); err != nil {
 		return err
 	}
-	if err := jsonutils.UnmarshalTOrSets(m, []jsonutils.UnmarshalTOrSet[string]{
+	if err := jsonutils.UnmarshalTOrSets(m, []jsonutils.UnmarshalTOrSet[Context]{
 		{Key: "@context", Target: &d.Context, Optional: true},
 	}); err != nil {
 		return err
@@ -89,6 +95,11 @@ func (d *Document) UnmarshalJSON(raw []byte) error {
 	}); err != nil {
 		return err
 	}
+	d.Extensions = jsonutils.UnknownFields(m, []string{
+		"@context", "id", "alsoKnownAs", "controller", "verificationMethod",
+		"authentication", "assertionMethod", "keyAgreement", "capabilityInvocation", "capabilityDelegation",
+		"service",
+	})
 	return nil
 }
 
@@ -100,9 +111,12 @@ type RelativeVerificationMethod struct {
 	RelativeURL string `json:"relativeURL"`
 }
 
+// Get returns the verification method with the same (equivalent) ID. Relative references only match relative IDs, use
+// Document.FindVerificationMethod to resolve them against the ID of the document, or a ReferenceResolver to resolve
+// references to the documents of other DIDs.
 func (v *RelativeVerificationMethod) Get(verificationMethods []VerificationMethod) *VerificationMethod {
 	for _, method := range verificationMethods {
-		if method.ID == v.RelativeURL {
+		if equalIDs(method.ID, v.RelativeURL) {
 			return &method
 		}
 	}
@@ -117,6 +131,8 @@ type Service struct {
 	ID              string          `json:"id"`
 	Type            []string        `json:"type"`
 	ServiceEndpoint ServiceEndpoint `json:"serviceEndpoint"`
+	// Extensions are all other properties of the service.
+	Extensions map[string]json.RawMessage `json:"-"`
 }
 
 func (s *Service) MarshalJSON() ([]byte, error) {
@@ -124,16 +140,16 @@ func (s *Service) MarshalJSON() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	var m map[string]any
-	if err := json.Unmarshal(raw, &m); err != nil {
+	m, err := jsonutils.DecodeObject(raw)
+	if err != nil {
 		return nil, err
 	}
 	if err := jsonutils.FlattenStringOrSetOrMap(m, []jsonutils.FlattenField{
 		{Name: "type"},
-		{Name: "serviceEndpoint"},
 	}); err != nil {
 		return nil, err
 	}
+	jsonutils.MergeExtensions(m, s.Extensions)
 	return json.Marshal(m)
 }
 
@@ -144,6 +160,7 @@ func (s *Service) UnmarshalJSON(raw []byte) error {
 	}
 	if err := jsonutils.UnmarshalFields(m, []jsonutils.UnmarshalField{
 		{Key: "id", Target: &s.ID},
+		{Key: "serviceEndpoint", Target: &s.ServiceEndpoint},
 	}); err != nil {
 		return err
 	}
@@ -152,32 +169,77 @@ func (s *Service) UnmarshalJSON(raw []byte) error {
 	}); err != nil {
 		return err
 	}
-	if err := jsonutils.UnmarshalTOrSetOrMap(m["serviceEndpoint"], (*map[string]string)(&s.ServiceEndpoint)); err != nil {
-		return err
-	}
+	s.Extensions = jsonutils.UnknownFields(m, []string{"id", "type", "serviceEndpoint"})
 	return nil
 }
 
-type ServiceEndpoint map[string]string
-
 type VerificationMethod struct {
-	ID                 string            `json:"id"`
-	Controller         string            `json:"controller"`
-	Type               string            `json:"type"`
-	PublicKeyJwk       map[string]string `json:"publicKeyJwk,omitempty"`
-	PublicKeyMultibase string            `json:"publicKeyMultibase,omitempty"`
+	ID                 string `json:"id"`
+	Controller         string `json:"controller"`
+	Type               string `json:"type"`
+	PublicKeyJwk       *JWK   `json:"publicKeyJwk,omitempty"`
+	PublicKeyMultibase string `json:"publicKeyMultibase,omitempty"`
+	// Legacy verification material, registered in the DID specification registries.
+	// DOCS: https://www.w3.org/TR/did-spec-registries/#verification-method-properties
+	PublicKeyBase58     string `json:"publicKeyBase58,omitempty"`
+	PublicKeyHex        string `json:"publicKeyHex,omitempty"`
+	BlockchainAccountID string `json:"blockchainAccountId,omitempty"`
+	EthereumAddress     string `json:"ethereumAddress,omitempty"`
+	// Extensions are all other properties of the verification method.
+	Extensions map[string]json.RawMessage `json:"-"`
 }
 
 func (v *VerificationMethod) Get(_ []VerificationMethod) *VerificationMethod {
 	return v
 }
 
+func (v *VerificationMethod) MarshalJSON() ([]byte, error) {
+	raw, err := json.Marshal(*v)
+	if err != nil {
+		return nil, err
+	}
+	if len(v.Extensions) == 0 {
+		return raw, nil
+	}
+	m, err := jsonutils.DecodeObject(raw)
+	if err != nil {
+		return nil, err
+	}
+	jsonutils.MergeExtensions(m, v.Extensions)
+	return json.Marshal(m)
+}
+
+func (v *VerificationMethod) UnmarshalJSON(raw []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return err
+	}
+	if err := jsonutils.UnmarshalFields(m, []jsonutils.UnmarshalField{
+		{Key: "id", Target: &v.ID, Optional: true},
+		{Key: "controller", Target: &v.Controller, Optional: true},
+		{Key: "type", Target: &v.Type, Optional: true},
+		{Key: "publicKeyJwk", Target: &v.PublicKeyJwk, Optional: true},
+		{Key: "publicKeyMultibase", Target: &v.PublicKeyMultibase, Optional: true},
+		{Key: "publicKeyBase58", Target: &v.PublicKeyBase58, Optional: true},
+		{Key: "publicKeyHex", Target: &v.PublicKeyHex, Optional: true},
+		{Key: "blockchainAccountId", Target: &v.BlockchainAccountID, Optional: true},
+		{Key: "ethereumAddress", Target: &v.EthereumAddress, Optional: true},
+	}); err != nil {
+		return err
+	}
+	v.Extensions = jsonutils.UnknownFields(m, []string{
+		"id", "controller", "type", "publicKeyJwk", "publicKeyMultibase",
+		"publicKeyBase58", "publicKeyHex", "blockchainAccountId", "ethereumAddress",
+	})
+	return nil
+}
+
 type VerificationMethods []VerificationMethod
 
 // Get returns the method with the given ID.
 func (v VerificationMethods) Get(id string) *VerificationMethod {
 	for _, method := range v {
-		if method.ID == id {
+		if equalIDs(method.ID, id) {
 			return &method
 		}
 	}
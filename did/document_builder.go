@@ -0,0 +1,154 @@
+package did
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+)
+
+// DocumentBuilder builds DID documents. Verification methods get fragment IDs relative to the DID and are added to
+// verification relationships by reference. The @context entries are derived from the verification method types and
+// the result is validated against the DID specification.
+type DocumentBuilder struct {
+	doc      Document
+	contexts []Context
+	keys     int
+	// err is the first error that occurred while building, it is returned by Build.
+	err error
+}
+
+// NewDocumentBuilder returns a builder for the DID document of the given DID.
+func NewDocumentBuilder(id DID) *DocumentBuilder {
+	return &DocumentBuilder{doc: Document{ID: id}}
+}
+
+// AlsoKnownAs appends the given URIs to the alsoKnownAs property.
+func (b *DocumentBuilder) AlsoKnownAs(uris ...string) *DocumentBuilder {
+	b.doc.AlsoKnownAs = append(b.doc.AlsoKnownAs, uris...)
+	return b
+}
+
+// Build returns the validated DID document.
+func (b *DocumentBuilder) Build() (*Document, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	doc := b.doc
+	urls := defaultContextURLs(doc.VerificationMethod)
+	for _, context := range b.contexts {
+		if !context.IsEmbedded() {
+			urls = appendUnique(urls, context.URL)
+		}
+	}
+	doc.Context = NewContexts(urls...)
+	for _, context := range b.contexts {
+		if context.IsEmbedded() {
+			doc.Context = append(doc.Context, context.Clone())
+		}
+	}
+	doc.AlsoKnownAs = append([]string(nil), doc.AlsoKnownAs...)
+	doc.Controller = append([]DID(nil), doc.Controller...)
+	doc.VerificationMethod = append(VerificationMethods(nil), doc.VerificationMethod...)
+	for _, r := range Relationships {
+		relationship := doc.relationship(r)
+		*relationship = append([]IVerificationMethod(nil), *relationship...)
+	}
+	doc.Service = append([]Service(nil), doc.Service...)
+	if err := doc.Validate().Err(); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// Context appends additional JSON-LD contexts, next to the ones of the DID specification and the verification method
+// types.
+func (b *DocumentBuilder) Context(contexts ...string) *DocumentBuilder {
+	b.contexts = append(b.contexts, NewContexts(contexts...)...)
+	return b
+}
+
+// EmbeddedContext appends an embedded context definition, after all context URLs.
+func (b *DocumentBuilder) EmbeddedContext(definition map[string]json.RawMessage) *DocumentBuilder {
+	b.contexts = append(b.contexts, Context{Definition: definition})
+	return b
+}
+
+// Controller appends the given DIDs to the controller property.
+func (b *DocumentBuilder) Controller(controllers ...DID) *DocumentBuilder {
+	b.doc.Controller = append(b.doc.Controller, controllers...)
+	return b
+}
+
+// Key adds a verification method for the given public key with the next free fragment ID ("#key-1", "#key-2", ...),
+// and references it from the given verification relationships. See NewVerificationMethod for the supported keys.
+func (b *DocumentBuilder) Key(key crypto.PublicKey, relationships ...Relationship) *DocumentBuilder {
+	var fragment string
+	for {
+		b.keys++
+		fragment = fmt.Sprintf("key-%d", b.keys)
+		if b.doc.FindVerificationMethod("#"+fragment) == nil {
+			break
+		}
+	}
+	return b.KeyWithFragment(fragment, key, relationships...)
+}
+
+// KeyWithFragment adds a verification method for the given public key with the given fragment ID, and references it
+// from the given verification relationships.
+func (b *DocumentBuilder) KeyWithFragment(fragment string, key crypto.PublicKey, relationships ...Relationship) *DocumentBuilder {
+	method, err := NewVerificationMethod("#"+percentEncode(fragment, isFragmentCharacter), b.doc.ID, key)
+	if err != nil {
+		b.fail(err)
+		return b
+	}
+	return b.Method(*method, relationships...)
+}
+
+// Method adds the given verification method, and references it from the given verification relationships.
+func (b *DocumentBuilder) Method(method VerificationMethod, relationships ...Relationship) *DocumentBuilder {
+	if b.doc.FindVerificationMethod(method.ID) != nil {
+		b.fail(fmt.Errorf("duplicate verification method: %s", method.ID))
+		return b
+	}
+	b.doc.VerificationMethod = append(b.doc.VerificationMethod, method)
+	for _, r := range relationships {
+		relationship := b.doc.relationship(r)
+		if relationship == nil {
+			b.fail(fmt.Errorf("unknown verification relationship: %s", r))
+			continue
+		}
+		*relationship = append(*relationship, &RelativeVerificationMethod{RelativeURL: method.ID})
+	}
+	return b
+}
+
+// Service adds a service with the given fragment ID, type and endpoint.
+func (b *DocumentBuilder) Service(fragment, typ string, endpoint ServiceEndpoint) *DocumentBuilder {
+	id := "#" + percentEncode(fragment, isFragmentCharacter)
+	if b.doc.FindService(id) != nil {
+		b.fail(fmt.Errorf("duplicate service: %s", id))
+		return b
+	}
+	b.doc.Service = append(b.doc.Service, Service{
+		ID:              id,
+		Type:            []string{typ},
+		ServiceEndpoint: endpoint,
+	})
+	return b
+}
+
+func (b *DocumentBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// appendUnique appends s to the slice, unless it is already contained in it.
+func appendUnique(slice []string, s string) []string {
+	for _, v := range slice {
+		if v == s {
+			return slice
+		}
+	}
+	return append(slice, s)
+}
@@ -0,0 +1,114 @@
+package did
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"github.com/0x51-dev/did/internal/multiformats"
+	"reflect"
+	"testing"
+)
+
+func TestNewVerificationMethod(t *testing.T) {
+	// SOURCE: https://w3c-ccg.github.io/did-method-key/#example-a-simple-ed25519-did-key-value
+	multibase := "z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"
+	_, b, _ := multiformats.DecodeMultibase(multibase)
+	controller, _ := ParseBareDID("did:key:" + multibase)
+	method, err := NewVerificationMethod("#"+multibase, *controller, ed25519.PublicKey(b[2:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method.Type != Multikey || method.PublicKeyMultibase != multibase || method.Controller != controller.String() {
+		t.Error(method)
+	}
+
+	p256, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if method, err := NewVerificationMethod("#key-1", *controller, &p256.PublicKey); err != nil || method.PublicKeyMultibase[:3] != "zDn" {
+		t.Error(method, err)
+	}
+	x25519, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	if method, err := NewVerificationMethod("#key-1", *controller, x25519.PublicKey()); err != nil || method.PublicKeyMultibase[:4] != "z6LS" {
+		t.Error(method, err)
+	}
+	p224, _ := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if _, err := NewVerificationMethod("#key-1", *controller, &p224.PublicKey); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestDocumentBuilder(t *testing.T) {
+	id, _ := ParseBareDID("did:example:123")
+	controller, _ := ParseBareDID("did:example:456")
+	signingKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	doc, err := NewDocumentBuilder(*id).
+		Controller(*id, *controller).
+		AlsoKnownAs("https://example.com/").
+		Key(signingKey, Authentication, AssertionMethod).
+		KeyWithFragment("key-2", &rsaKey.PublicKey, CapabilityInvocation).
+		Key(signingKey, CapabilityDelegation).
+		Service("files", "LinkedDomains", NewURIServiceEndpoint("https://example.com/files/")).
+		EmbeddedContext(map[string]json.RawMessage{"@vocab": json.RawMessage(`"https://example.com/vocab#"`)}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	embedded := Context{Definition: map[string]json.RawMessage{"@vocab": json.RawMessage(`"https://example.com/vocab#"`)}}
+	if !reflect.DeepEqual(doc.Context, append(NewContexts(ContextV1, MethodTypes[Multikey].Contexts[0], MethodTypes[JsonWebKey2020].Contexts[0]), embedded)) {
+		t.Error(doc.Context)
+	}
+	var ids []string
+	for _, method := range doc.VerificationMethod {
+		ids = append(ids, method.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"#key-1", "#key-2", "#key-3"}) {
+		t.Error(ids)
+	}
+	for id, relationships := range map[string][]Relationship{
+		"#key-1": {Authentication, AssertionMethod},
+		"#key-2": {CapabilityInvocation},
+		"#key-3": {CapabilityDelegation},
+	} {
+		if r := doc.RelationshipsOf(id); !reflect.DeepEqual(r, relationships) {
+			t.Error(id, r)
+		}
+	}
+	if doc.FindService("#files") == nil {
+		t.Error("missing service")
+	}
+
+	// The result can be parsed strictly.
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseDocument(raw, ParseOptions{Strict: true}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDocumentBuilder_errors(t *testing.T) {
+	id, _ := ParseBareDID("did:example:123")
+	key, _, _ := ed25519.GenerateKey(rand.Reader)
+	for _, b := range []*DocumentBuilder{
+		NewDocumentBuilder(*id).Key("key"),
+		NewDocumentBuilder(*id).Key(key, "unknown"),
+		NewDocumentBuilder(*id).KeyWithFragment("key", key).KeyWithFragment("key", key),
+		NewDocumentBuilder(*id).Service("s", "T", NewURIServiceEndpoint("https://example.com")).Service("s", "T", NewURIServiceEndpoint("https://example.com")),
+		NewDocumentBuilder(*id).AlsoKnownAs("example.com"),
+	} {
+		if _, err := b.Build(); err == nil {
+			t.Error("expected an error")
+		}
+	}
+	_, err := NewDocumentBuilder(*id).Service("s", "T", ServiceEndpoint{}).Build()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,114 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/0x51-dev/did/internal/jsonutils"
+	"time"
+)
+
+// DocumentMetadata are metadata about the DID document, e.g. its versions and whether the DID is deactivated. They
+// are returned by resolvers next to the document, and are not part of the document itself.
+// DOCS: https://www.w3.org/TR/did-core/#did-document-metadata
+type DocumentMetadata struct {
+	// The timestamp of the Create operation, if known.
+	Created time.Time
+	// The timestamp of the last Update operation, if the document was updated.
+	Updated time.Time
+	// Whether the DID has been deactivated.
+	Deactivated bool
+	// The timestamp of the next Update operation, if the resolved document version is not the latest one.
+	NextUpdate time.Time
+	// The version of the last Update operation, as used by the versionId DID parameter.
+	VersionID string
+	// The version of the next Update operation, if the resolved document version is not the latest one.
+	NextVersionID string
+	// DIDs that are logically equivalent to the resolved DID, produced by the same DID method.
+	EquivalentID []DID
+	// The canonical DID, which should be used instead of the resolved DID.
+	CanonicalID *DID
+	// Extensions are all other (method-specific) metadata properties.
+	Extensions map[string]json.RawMessage
+}
+
+func (m DocumentMetadata) MarshalJSON() ([]byte, error) {
+	timestamp := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(versionTimeLayout)
+	}
+	raw, err := json.Marshal(struct {
+		Created       string `json:"created,omitempty"`
+		Updated       string `json:"updated,omitempty"`
+		Deactivated   bool   `json:"deactivated,omitempty"`
+		NextUpdate    string `json:"nextUpdate,omitempty"`
+		VersionID     string `json:"versionId,omitempty"`
+		NextVersionID string `json:"nextVersionId,omitempty"`
+		EquivalentID  []DID  `json:"equivalentId,omitempty"`
+		CanonicalID   *DID   `json:"canonicalId,omitempty"`
+	}{
+		Created:       timestamp(m.Created),
+		Updated:       timestamp(m.Updated),
+		Deactivated:   m.Deactivated,
+		NextUpdate:    timestamp(m.NextUpdate),
+		VersionID:     m.VersionID,
+		NextVersionID: m.NextVersionID,
+		EquivalentID:  m.EquivalentID,
+		CanonicalID:   m.CanonicalID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(m.Extensions) == 0 {
+		return raw, nil
+	}
+	o, err := jsonutils.DecodeObject(raw)
+	if err != nil {
+		return nil, err
+	}
+	jsonutils.MergeExtensions(o, m.Extensions)
+	return json.Marshal(o)
+}
+
+func (m *DocumentMetadata) UnmarshalJSON(raw []byte) error {
+	var o map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &o); err != nil {
+		return err
+	}
+	var created, updated, nextUpdate string
+	if err := jsonutils.UnmarshalFields(o, []jsonutils.UnmarshalField{
+		{Key: "created", Target: &created, Optional: true},
+		{Key: "updated", Target: &updated, Optional: true},
+		{Key: "deactivated", Target: &m.Deactivated, Optional: true},
+		{Key: "nextUpdate", Target: &nextUpdate, Optional: true},
+		{Key: "versionId", Target: &m.VersionID, Optional: true},
+		{Key: "nextVersionId", Target: &m.NextVersionID, Optional: true},
+		{Key: "equivalentId", Target: &m.EquivalentID, Optional: true},
+		{Key: "canonicalId", Target: &m.CanonicalID, Optional: true},
+	}); err != nil {
+		return fmt.Errorf("invalid DID document metadata: %w", err)
+	}
+	for _, t := range []struct {
+		key    string
+		value  string
+		target *time.Time
+	}{
+		{"created", created, &m.Created},
+		{"updated", updated, &m.Updated},
+		{"nextUpdate", nextUpdate, &m.NextUpdate},
+	} {
+		if t.value == "" {
+			continue
+		}
+		v, err := time.Parse(time.RFC3339, t.value)
+		if err != nil {
+			return fmt.Errorf("invalid DID document metadata: %s: %w", t.key, err)
+		}
+		*t.target = v.UTC()
+	}
+	m.Extensions = jsonutils.UnknownFields(o, []string{
+		"created", "updated", "deactivated", "nextUpdate", "versionId", "nextVersionId", "equivalentId", "canonicalId",
+	})
+	return nil
+}
@@ -0,0 +1,66 @@
+package did
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDocumentMetadata_JSON(t *testing.T) {
+	// SOURCE: https://www.w3.org/TR/did-core/#example-did-document-metadata
+	raw := `{"canonicalId":"did:example:456","created":"2019-03-23T06:35:22Z","deactivated":true,"equivalentId":["did:example:789"],"method":{"published":true},"nextUpdate":"2021-05-10T17:00:00Z","nextVersionId":"3","updated":"2023-08-10T13:40:06Z","versionId":"2"}`
+	var metadata DocumentMetadata
+	if err := json.Unmarshal([]byte(raw), &metadata); err != nil {
+		t.Fatal(err)
+	}
+	if !metadata.Created.Equal(time.Date(2019, 3, 23, 6, 35, 22, 0, time.UTC)) {
+		t.Error(metadata.Created)
+	}
+	if !metadata.Deactivated || metadata.VersionID != "2" || metadata.NextVersionID != "3" {
+		t.Error(metadata)
+	}
+	if metadata.CanonicalID.String() != "did:example:456" || len(metadata.EquivalentID) != 1 {
+		t.Error(metadata.CanonicalID, metadata.EquivalentID)
+	}
+	if string(metadata.Extensions["method"]) != `{"published":true}` {
+		t.Error(metadata.Extensions)
+	}
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != raw {
+		t.Error(string(b))
+	}
+
+	// Timestamps are normalized to UTC, without sub-second precision.
+	metadata = DocumentMetadata{Updated: time.Date(2021, 5, 10, 19, 0, 0, 500, time.FixedZone("CEST", 2*60*60))}
+	if b, _ := json.Marshal(metadata); string(b) != `{"updated":"2021-05-10T17:00:00Z"}` {
+		t.Error(string(b))
+	}
+	if b, _ := json.Marshal(DocumentMetadata{}); string(b) != `{}` {
+		t.Error(string(b))
+	}
+	if err := json.Unmarshal([]byte(`{"created":"yesterday"}`), &metadata); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestResolutionResult_JSON(t *testing.T) {
+	id, _ := ParseBareDID("did:example:123")
+	result := ResolutionResult{
+		Metadata:         Metadata{ContentType: "application/did+json"},
+		Document:         &Document{ID: *id},
+		DocumentMetadata: DocumentMetadata{VersionID: "1"},
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"didResolutionMetadata":{"contentType":"application/did+json"},"didDocument":{"id":"did:example:123"},"didDocumentMetadata":{"versionId":"1"}}` {
+		t.Error(string(b))
+	}
+	if b, _ := json.Marshal(ResolutionResult{Metadata: Metadata{Error: NotFoundError}}); string(b) != `{"didResolutionMetadata":{"error":"notFound"},"didDocument":null,"didDocumentMetadata":{}}` {
+		t.Error(string(b))
+	}
+}
@@ -2,7 +2,11 @@ package did
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
+	"testing"
 )
 
 var (
@@ -20,6 +24,8 @@ var (
 	example14 []byte
 	//go:embed testdata/example20.json
 	example20 []byte
+	//go:embed testdata/example22.json
+	example22 []byte
 )
 
 func ExampleDID_controller() {
@@ -154,3 +160,51 @@ func ExampleDocument_service() {
 	// 	]
 	// }
 }
+
+func TestParseDocument_invalidID(t *testing.T) {
+	for _, test := range []string{
+		`{"id": "did:example:123#key-1"}`,
+		`{"id": "did:example:123/path"}`,
+		`{"id": "did:example:123", "controller": "did:example:456?versionId=1"}`,
+		`{"id": "did:example:123", "controller": ["did:example:456", "did:example:789;service=agent"]}`,
+	} {
+		if _, err := ParseDocument([]byte(test)); err == nil {
+			t.Errorf("expected error for %s", test)
+		}
+	}
+}
+
+func TestDocument_extensions(t *testing.T) {
+	doc, err := ParseDocument(example22)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := string(doc.Extensions["deactivated"]); v != "false" {
+		t.Error(v)
+	}
+	if v := string(doc.VerificationMethod[0].Extensions["revoked"]); v != `"2023-01-01T00:00:00Z"` {
+		t.Error(v)
+	}
+	if v := string(doc.Service[0].Extensions["priority"]); v != "1.50" {
+		t.Error(v)
+	}
+
+	raw, err := doc.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected, actual any
+	if err := json.Unmarshal(example22, &expected); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(raw, &actual); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("round trip mismatch:\n%s", raw)
+	}
+	// Numbers are preserved as is.
+	if !strings.Contains(string(raw), "12345678901234567890") || !strings.Contains(string(raw), "1.50") {
+		t.Error(string(raw))
+	}
+}
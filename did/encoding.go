@@ -0,0 +1,122 @@
+package did
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// format formats the string representation of a DID (URL), supporting the %s, %v and %q verbs.
+func format(f fmt.State, verb rune, typ string, s string) {
+	switch verb {
+	case 's', 'v', 'q':
+		_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), s)
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(%s=%s)", verb, typ, s)
+	}
+}
+
+// scan converts a database value to a string.
+func scan(src any) (string, bool, error) {
+	switch src := src.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return src, true, nil
+	case []byte:
+		return string(src), true, nil
+	default:
+		return "", false, fmt.Errorf("unsupported type: %T", src)
+	}
+}
+
+// Format implements fmt.Formatter.
+func (d DID) Format(f fmt.State, verb rune) {
+	format(f, verb, "did.DID", d.String())
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d DID) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Scan implements sql.Scanner. A NULL value results in the zero DID.
+func (d *DID) Scan(src any) error {
+	s, ok, err := scan(src)
+	if err != nil {
+		return fmt.Errorf("invalid DID: %w", err)
+	}
+	if !ok {
+		*d = DID{}
+		return nil
+	}
+	return d.Set(s)
+}
+
+// Set implements flag.Value.
+func (d *DID) Set(s string) error {
+	u, err := ParseBareDID(s)
+	if err != nil {
+		return err
+	}
+	*d = *u
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *DID) UnmarshalText(text []byte) error {
+	return d.Set(string(text))
+}
+
+// Value implements driver.Valuer. The zero DID is stored as NULL.
+func (d DID) Value() (driver.Value, error) {
+	if d.Method == "" && len(d.MethodIDs) == 0 {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+// Format implements fmt.Formatter.
+func (d DIDURL) Format(f fmt.State, verb rune) {
+	format(f, verb, "did.DIDURL", d.String())
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d DIDURL) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Scan implements sql.Scanner. A NULL value results in the zero DID URL.
+func (d *DIDURL) Scan(src any) error {
+	s, ok, err := scan(src)
+	if err != nil {
+		return fmt.Errorf("invalid DID URL: %w", err)
+	}
+	if !ok {
+		*d = DIDURL{}
+		return nil
+	}
+	return d.Set(s)
+}
+
+// Set implements flag.Value.
+func (d *DIDURL) Set(s string) error {
+	u, err := ParseDIDURL(s)
+	if err != nil {
+		return err
+	}
+	*d = *u
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *DIDURL) UnmarshalText(text []byte) error {
+	return d.Set(string(text))
+}
+
+// Value implements driver.Valuer. The zero DID URL is stored as NULL.
+func (d DIDURL) Value() (driver.Value, error) {
+	if d.Method == "" && len(d.MethodIDs) == 0 {
+		return nil, nil
+	}
+	return d.String(), nil
+}
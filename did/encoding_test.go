@@ -0,0 +1,133 @@
+package did_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/0x51-dev/did/did"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = did.DID{}
+	_ encoding.TextUnmarshaler = (*did.DID)(nil)
+	_ sql.Scanner              = (*did.DID)(nil)
+	_ driver.Valuer            = did.DID{}
+	_ flag.Value               = (*did.DID)(nil)
+	_ fmt.Formatter            = did.DID{}
+	_ encoding.TextMarshaler   = did.DIDURL{}
+	_ encoding.TextUnmarshaler = (*did.DIDURL)(nil)
+	_ sql.Scanner              = (*did.DIDURL)(nil)
+	_ driver.Valuer            = did.DIDURL{}
+	_ flag.Value               = (*did.DIDURL)(nil)
+	_ fmt.Formatter            = did.DIDURL{}
+)
+
+func ExampleDID_Set() {
+	var d did.DID
+	fs := flag.NewFlagSet("example", flag.ContinueOnError)
+	fs.Var(&d, "did", "the DID to resolve")
+	_ = fs.Parse([]string{"-did", "did:web:example.com"})
+	fmt.Println(d)
+	// Output:
+	// did:web:example.com
+}
+
+func TestDID_Format(t *testing.T) {
+	d, _ := did.ParseBareDID("did:example:123")
+	for _, test := range []struct {
+		format   string
+		expected string
+	}{
+		{"%s", "did:example:123"},
+		{"%v", "did:example:123"},
+		{"%+v", "did:example:123"},
+		{"%q", `"did:example:123"`},
+		{"%20s", "     did:example:123"},
+		{"%d", "%!d(did.DID=did:example:123)"},
+	} {
+		if s := fmt.Sprintf(test.format, d); s != test.expected {
+			t.Error(s, test.expected)
+		}
+		if s := fmt.Sprintf(test.format, *d); s != test.expected {
+			t.Error(s, test.expected)
+		}
+	}
+	u, _ := did.ParseDIDURL("did:example:123#key-1")
+	if s := fmt.Sprintf("%v", []did.DIDURL{*u}); s != "[did:example:123#key-1]" {
+		t.Error(s)
+	}
+}
+
+func TestDID_sql(t *testing.T) {
+	d, _ := did.ParseBareDID("did:example:123")
+	v, err := d.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var scanned did.DID
+	if err := scanned.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if !scanned.Equal(*d) {
+		t.Error(scanned, d)
+	}
+	if err := scanned.Scan([]byte("did:example:456")); err != nil {
+		t.Fatal(err)
+	}
+	if scanned.String() != "did:example:456" {
+		t.Error(scanned)
+	}
+	if err := scanned.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := scanned.Value(); v != nil {
+		t.Error(v)
+	}
+	for _, src := range []any{42, "did:example:123#key-1"} {
+		if err := scanned.Scan(src); err == nil {
+			t.Errorf("expected error for %v", src)
+		}
+	}
+}
+
+func TestDID_text(t *testing.T) {
+	type config struct {
+		Subject did.DID               `json:"subject"`
+		Keys    []did.DIDURL          `json:"keys"`
+		Aliases map[string]did.DIDURL `json:"aliases"`
+	}
+	raw := []byte(`{"subject":"did:example:123","keys":["did:example:123#key-1"],"aliases":{"agent":"did:example:123?service=agent"}}`)
+	var c config
+	if err := json.Unmarshal(raw, &c); err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != string(raw) {
+		t.Error(string(out))
+	}
+
+	for _, test := range []string{"did:example:123", "did:web:localhost%3A8443:user"} {
+		var d did.DID
+		if err := d.UnmarshalText([]byte(test)); err != nil {
+			t.Fatal(err)
+		}
+		text, err := d.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != test {
+			t.Error(string(text), test)
+		}
+	}
+	var d did.DID
+	if err := d.UnmarshalText([]byte("did:example:123#key-1")); err == nil {
+		t.Error("expected error")
+	}
+}
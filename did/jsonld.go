@@ -0,0 +1,184 @@
+package did
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/0x51-dev/did/internal/jsonld"
+	"github.com/0x51-dev/did/internal/jsonutils"
+)
+
+// Media types of the representations of DID documents.
+// DOCS: https://www.w3.org/TR/did-core/#representations
+const (
+	MediaTypeJSON   = "application/did+json"
+	MediaTypeJSONLD = "application/did+ld+json"
+)
+
+// ContextLoader loads the remote JSON-LD context document of the given URL, a JSON object (decoded with
+// json.Decoder.UseNumber) with a @context entry.
+type ContextLoader func(url string) (any, error)
+
+// JSONLDOptions are options for processing the JSON-LD representation of DID documents.
+type JSONLDOptions struct {
+	// Loader loads the remote contexts, defaults to OfflineContextLoader.
+	Loader ContextLoader
+}
+
+// OfflineContextLoader loads the bundled DID (v1, v1.1), security (v1, v2), data integrity (v1, v2), controlled
+// identifier, Multikey, JWK and verification method suite contexts, see the MethodTypes. The bundled contexts are copies
+// of the published context documents, including the terms of proofs and signatures. Other contexts can not be loaded,
+// the network is never accessed.
+func OfflineContextLoader(url string) (any, error) {
+	return jsonld.OfflineLoader(url)
+}
+
+// CompactDocument compacts the expanded JSON-LD representation of a DID document with the given contexts, which
+// become the @context of the document. See Document.Expand.
+// DOCS: https://www.w3.org/TR/json-ld11-api/#compaction-algorithm
+func CompactDocument(expanded []any, contexts []Context, options ...JSONLDOptions) (*Document, error) {
+	context, err := contextValue(contexts)
+	if err != nil {
+		return nil, err
+	}
+	compacted, err := jsonld.Compact(expanded, context, jsonLDOptions(options))
+	if err != nil {
+		return nil, err
+	}
+	// Properties without @set container are compacted to single values, the (JSON) representation requires sets.
+	for _, property := range []string{"alsoKnownAs", "verificationMethod", "service"} {
+		if v, ok := compacted[property]; ok {
+			if _, ok := v.([]any); !ok {
+				compacted[property] = []any{v}
+			}
+		}
+	}
+	raw, err := json.Marshal(compacted)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDocument(raw)
+}
+
+// ParseDocumentJSONLD parses the JSON-LD representation (application/did+ld+json) of a DID document: the @context is
+// required and validated, see Document.ValidateJSONLD.
+func ParseDocumentJSONLD(raw []byte, options ...JSONLDOptions) (*Document, error) {
+	doc, err := ParseDocument(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.ValidateJSONLD(options...).Err(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// contextValue returns the (decoded) value of the @context property.
+func contextValue(contexts []Context) (any, error) {
+	if len(contexts) == 0 {
+		return nil, nil
+	}
+	var raw []byte
+	var err error
+	if len(contexts) == 1 {
+		raw, err = json.Marshal(contexts[0])
+	} else {
+		raw, err = json.Marshal(contexts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jsonutils.Decode(raw)
+}
+
+// jsonLDOptions returns the options of the JSON-LD processor.
+func jsonLDOptions(options []JSONLDOptions) jsonld.Options {
+	o := jsonld.Options{Loader: OfflineContextLoader}
+	for _, option := range options {
+		if option.Loader != nil {
+			o.Loader = jsonld.Loader(option.Loader)
+		}
+	}
+	return o
+}
+
+// Expand returns the expanded JSON-LD representation of the document, in which all terms are replaced by IRIs as
+// defined by the @context. Properties that are not defined by the @context are dropped.
+// DOCS: https://www.w3.org/TR/json-ld11-api/#expansion-algorithm
+func (d *Document) Expand(options ...JSONLDOptions) ([]any, error) {
+	raw, err := d.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	input, err := jsonutils.Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	return jsonld.Expand(input, jsonLDOptions(options))
+}
+
+// MarshalJSONLD returns the JSON-LD representation (application/did+ld+json) of the document. If the document has no
+// @context, it gets the context of the DID specification and the contexts of its verification method types.
+func (d *Document) MarshalJSONLD() ([]byte, error) {
+	if len(d.Context) != 0 {
+		return d.MarshalJSON()
+	}
+	c := *d
+	c.Context = NewContexts(defaultContextURLs(d.VerificationMethod)...)
+	return c.MarshalJSON()
+}
+
+// ValidateJSONLD checks the document against the requirements of the JSON-LD representation: the @context is required
+// and must start with the context of the DID specification, all contexts must be processable (e.g. protected terms
+// must not be redefined), and all properties should be defined by the contexts.
+// DOCS: https://www.w3.org/TR/did-core/#json-ld
+func (d *Document) ValidateJSONLD(options ...JSONLDOptions) Violations {
+	var v Violations
+	switch {
+	case len(d.Context) == 0:
+		v.add("$['@context']", SeverityError, "missing @context, required for the JSON-LD representation")
+		return v
+	case d.Context[0].URL != ContextV1 && d.Context[0].URL != ContextV11:
+		v.add("$['@context'][0]", SeverityError, "the first context must be %s", ContextV1)
+	}
+	seen := make(map[string]bool)
+	for i, context := range d.Context {
+		if context.IsEmbedded() {
+			continue
+		}
+		if !isURI(context.URL) {
+			v.add(fmt.Sprintf("$['@context'][%d]", i), SeverityError, "%q is not a URL", context.URL)
+		} else if seen[context.URL] {
+			v.add(fmt.Sprintf("$['@context'][%d]", i), SeverityWarning, "duplicate context %s", context.URL)
+		}
+		seen[context.URL] = true
+	}
+
+	raw, err := d.MarshalJSON()
+	if err != nil {
+		v.add("$", SeverityError, "%v", err)
+		return v
+	}
+	input, err := jsonutils.Decode(raw)
+	if err != nil {
+		v.add("$", SeverityError, "%v", err)
+		return v
+	}
+	o := jsonLDOptions(options)
+	o.Strict = true
+	if _, err := jsonld.Expand(input, o); err != nil {
+		errs := []error{err}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = joined.Unwrap()
+		}
+		for _, err := range errs {
+			var undefinedErr *jsonld.UndefinedTermError
+			if errors.As(err, &undefinedErr) {
+				v.add(undefinedErr.Path, SeverityWarning, "%s is not defined by the @context", undefinedErr.Term)
+				continue
+			}
+			v.add("$['@context']", SeverityError, "%v", err)
+		}
+	}
+	return v
+}
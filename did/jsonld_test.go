@@ -0,0 +1,235 @@
+package did
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDocument_Expand(t *testing.T) {
+	doc, err := ParseDocument(example21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expanded, err := doc.Expand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := expanded[0].(map[string]any)
+	if node["@id"] != "did:example:123" {
+		t.Error(node["@id"])
+	}
+	authentication := node["https://w3id.org/security#authenticationMethod"].([]any)
+	if len(authentication) != 2 || authentication[0].(map[string]any)["@id"] != "#keys-1" {
+		t.Error(authentication)
+	}
+	method := authentication[1].(map[string]any)
+	if !reflect.DeepEqual(method["@type"], []any{"https://w3id.org/security#Ed25519VerificationKey2020"}) {
+		t.Error(method["@type"])
+	}
+	if _, ok := method["https://w3id.org/security#publicKeyMultibase"]; !ok {
+		t.Error(method)
+	}
+
+	compacted, err := CompactDocument(expanded, doc.Context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes, err := Diff(doc, compacted); err != nil || len(changes) != 0 {
+		t.Error(changes, err)
+	}
+}
+
+func TestDocument_Expand_embeddedContext(t *testing.T) {
+	raw := []byte(`{
+		"@context": ["https://www.w3.org/ns/did/v1", {"@vocab": "https://example.com/vocab#", "homepage": {"@id": "http://xmlns.com/foaf/0.1/homepage", "@type": "@id"}}],
+		"id": "did:example:123",
+		"homepage": "https://example.com/",
+		"nickname": "example"
+	}`)
+	doc, err := ParseDocumentJSONLD(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !doc.Context[1].IsEmbedded() {
+		t.Error(doc.Context)
+	}
+	if v := doc.ValidateJSONLD(); len(v) != 0 {
+		t.Error(v)
+	}
+	expanded, err := doc.Expand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := expanded[0].(map[string]any)
+	if !reflect.DeepEqual(node["http://xmlns.com/foaf/0.1/homepage"], []any{map[string]any{"@id": "https://example.com/"}}) {
+		t.Error(node)
+	}
+	if _, ok := node["https://example.com/vocab#nickname"]; !ok {
+		t.Error(node)
+	}
+	compacted, err := CompactDocument(expanded, doc.Context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes, err := Diff(doc, compacted); err != nil || len(changes) != 0 {
+		t.Error(changes, err)
+	}
+}
+
+func TestDocument_ValidateJSONLD(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		raw      string
+		errors   []string
+		warnings []string
+	}{
+		{
+			name: "valid",
+			raw:  `{"@context": ["https://www.w3.org/ns/did/v1", "https://w3id.org/security/suites/jws-2020/v1"], "id": "did:example:123", "verificationMethod": [{"id": "#key-1", "type": "JsonWebKey2020", "controller": "did:example:123", "publicKeyJwk": {"kty": "OKP", "crv": "Ed25519", "x": "abc"}}]}`,
+		},
+		{
+			name:   "missing context",
+			raw:    `{"id": "did:example:123"}`,
+			errors: []string{"$['@context']"},
+		},
+		{
+			name:   "first context",
+			raw:    `{"@context": ["https://w3id.org/security/suites/jws-2020/v1", "https://www.w3.org/ns/did/v1"], "id": "did:example:123"}`,
+			errors: []string{"$['@context'][0]"},
+		},
+		{
+			name:     "duplicate context",
+			raw:      `{"@context": ["https://www.w3.org/ns/did/v1", "https://www.w3.org/ns/did/v1"], "id": "did:example:123"}`,
+			warnings: []string{"$['@context'][1]"},
+		},
+		{
+			name:   "unknown context",
+			raw:    `{"@context": ["https://www.w3.org/ns/did/v1", "https://example.com/context.jsonld"], "id": "did:example:123"}`,
+			errors: []string{"$['@context']"},
+		},
+		{
+			name:   "protected term redefinition",
+			raw:    `{"@context": ["https://www.w3.org/ns/did/v1", {"controller": "https://example.com/controller"}], "id": "did:example:123"}`,
+			errors: []string{"$['@context']"},
+		},
+		{
+			name:     "undefined terms",
+			raw:      `{"@context": "https://www.w3.org/ns/did/v1", "id": "did:example:123", "verificationMethod": [{"id": "#key-1", "type": "Multikey", "controller": "did:example:123", "publicKeyMultibase": "z6Mk"}], "nickname": "example"}`,
+			warnings: []string{"$.nickname", "$.verificationMethod[0].publicKeyMultibase"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var doc Document
+			if err := json.Unmarshal([]byte(test.raw), &doc); err != nil {
+				t.Fatal(err)
+			}
+			var errors, warnings []string
+			for _, v := range doc.ValidateJSONLD() {
+				if v.Severity == SeverityError {
+					errors = append(errors, v.Path)
+				} else {
+					warnings = append(warnings, v.Path)
+				}
+			}
+			if !reflect.DeepEqual(errors, test.errors) || !reflect.DeepEqual(warnings, test.warnings) {
+				t.Error(doc.ValidateJSONLD())
+			}
+			if _, err := ParseDocumentJSONLD([]byte(test.raw)); (err != nil) != (test.errors != nil) {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestDocument_MarshalJSONLD(t *testing.T) {
+	id, _ := ParseBareDID("did:example:123")
+	doc := Document{ID: *id, VerificationMethod: VerificationMethods{{ID: "#key-1", Type: Ed25519VerificationKey2020, Controller: "did:example:123", PublicKeyMultibase: "z6Mk"}}}
+	raw, err := doc.MarshalJSONLD()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ld, err := ParseDocumentJSONLD(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ld.Context, NewContexts(ContextV1, MethodTypes[Ed25519VerificationKey2020].Contexts[0])) {
+		t.Error(ld.Context)
+	}
+	if len(doc.Context) != 0 {
+		t.Error("the document was modified")
+	}
+}
+
+func TestParseDocumentJSONLD_securityContext(t *testing.T) {
+	doc, err := ParseDocumentJSONLD([]byte(`{
+		"@context": ["https://www.w3.org/ns/did/v1", "https://w3id.org/security/v2"],
+		"id": "did:example:123",
+		"verificationMethod": [{
+			"id": "did:example:123#key-1",
+			"type": "Ed25519VerificationKey2018",
+			"controller": "did:example:123",
+			"publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+		}],
+		"authentication": ["did:example:123#key-1"]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := doc.ValidateJSONLD(); len(v) != 0 {
+		t.Error(v)
+	}
+	if _, err := ParseDocumentJSONLD([]byte(`{"@context": ["https://www.w3.org/ns/did/v1", "https://w3id.org/security/v1"], "id": "did:example:123"}`)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDocument_ValidateJSONLD_proof(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		contexts string
+		method   string
+		proof    string
+	}{
+		{
+			name:     "Ed25519Signature2020",
+			contexts: `"https://w3id.org/security/suites/ed25519-2020/v1"`,
+			method:   `"type": "Ed25519VerificationKey2020", "publicKeyMultibase": "z6Mk"`,
+			proof:    `"type": "Ed25519Signature2020", "created": "2024-01-01T00:00:00Z", "verificationMethod": "did:example:123#key-1", "proofPurpose": "assertionMethod", "proofValue": "z58D"`,
+		},
+		{
+			name:     "JsonWebSignature2020",
+			contexts: `"https://w3id.org/security/suites/jws-2020/v1", "https://w3id.org/security/data-integrity/v2"`,
+			method:   `"type": "JsonWebKey2020", "publicKeyJwk": {"kty": "OKP", "crv": "Ed25519", "x": "abc"}`,
+			proof:    `"type": "JsonWebSignature2020", "created": "2024-01-01T00:00:00Z", "verificationMethod": "did:example:123#key-1", "proofPurpose": "assertionMethod", "jws": "eyJhbGciOiJFZERTQSJ9..abc"`,
+		},
+		{
+			name:     "DataIntegrityProof",
+			contexts: `"https://w3id.org/security/multikey/v1", "https://w3id.org/security/data-integrity/v2"`,
+			method:   `"type": "Multikey", "publicKeyMultibase": "z6Mk"`,
+			proof:    `"type": "DataIntegrityProof", "cryptosuite": "eddsa-rdfc-2022", "created": "2024-01-01T00:00:00Z", "verificationMethod": "did:example:123#key-1", "proofPurpose": "assertionMethod", "proofValue": "z58D"`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := ParseDocumentJSONLD([]byte(`{
+				"@context": ["https://www.w3.org/ns/did/v1", ` + test.contexts + `],
+				"id": "did:example:123",
+				"verificationMethod": [{"id": "did:example:123#key-1", "controller": "did:example:123", ` + test.method + `}],
+				"proof": {` + test.proof + `}
+			}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v := doc.ValidateJSONLD(); len(v) != 0 {
+				t.Error(v)
+			}
+			expanded, err := doc.Expand()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := expanded[0].(map[string]any)["https://w3id.org/security#proof"]; !ok {
+				t.Error(expanded)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package did
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/0x51-dev/did/internal/jsonutils"
+	"math/big"
+)
+
+// privateJWKMembers are the members of a JWK that contain private key material.
+// DOCS: https://www.rfc-editor.org/rfc/rfc7518#section-6
+var privateJWKMembers = []string{"d", "p", "q", "dp", "dq", "qi", "oth", "k"}
+
+// JWK is a public JSON Web Key, of key type OKP, EC or RSA. Private key members are rejected.
+// DOCS: https://www.rfc-editor.org/rfc/rfc7517
+type JWK struct {
+	// Common members.
+	// DOCS: https://www.rfc-editor.org/rfc/rfc7517#section-4
+	Kty     string   `json:"kty"`
+	Use     string   `json:"use,omitempty"`
+	KeyOps  []string `json:"key_ops,omitempty"`
+	Alg     string   `json:"alg,omitempty"`
+	Kid     string   `json:"kid,omitempty"`
+	X5u     string   `json:"x5u,omitempty"`
+	X5c     []string `json:"x5c,omitempty"`
+	X5t     string   `json:"x5t,omitempty"`
+	X5tS256 string   `json:"x5t#S256,omitempty"`
+	// Elliptic curve (EC) and octet key pair (OKP) members.
+	// DOCS: https://www.rfc-editor.org/rfc/rfc7518#section-6.2, https://www.rfc-editor.org/rfc/rfc8037#section-2
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	// RSA members.
+	// DOCS: https://www.rfc-editor.org/rfc/rfc7518#section-6.3
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Extensions are all other (public) members of the JWK.
+	Extensions map[string]json.RawMessage `json:"-"`
+}
+
+// NewJWK returns the JWK of the given public key: an ed25519.PublicKey, an *ecdh.PublicKey (X25519), an
+// *ecdsa.PublicKey (P-256, P-384 or P-521), a Secp256k1PublicKey or an *rsa.PublicKey.
+func NewJWK(key crypto.PublicKey) (*JWK, error) {
+	encode := base64.RawURLEncoding.EncodeToString
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		return &JWK{Kty: "OKP", Crv: CurveEd25519, X: encode(key)}, nil
+	case *ecdh.PublicKey:
+		if key.Curve() != ecdh.X25519() {
+			return nil, fmt.Errorf("unsupported ECDH curve: %s", key.Curve())
+		}
+		return &JWK{Kty: "OKP", Crv: CurveX25519, X: encode(key.Bytes())}, nil
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		var crv string
+		switch key.Curve {
+		case elliptic.P256():
+			crv = "P-256"
+		case elliptic.P384():
+			crv = "P-384"
+		case elliptic.P521():
+			crv = "P-521"
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve: %s", key.Curve.Params().Name)
+		}
+		return &JWK{Kty: "EC", Crv: crv, X: encode(key.X.FillBytes(make([]byte, size))), Y: encode(key.Y.FillBytes(make([]byte, size)))}, nil
+	case Secp256k1PublicKey:
+		x, y := key.Coordinates()
+		return &JWK{Kty: "EC", Crv: CurveSecp256k1, X: encode(x.FillBytes(make([]byte, 32))), Y: encode(y.FillBytes(make([]byte, 32)))}, nil
+	case *rsa.PublicKey:
+		return &JWK{Kty: "RSA", N: encode(key.N.Bytes()), E: encode(big.NewInt(int64(key.E)).Bytes())}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type: %T", key)
+	}
+}
+
+// Clone returns a deep copy of the JWK.
+func (k *JWK) Clone() *JWK {
+	c := *k
+	c.KeyOps = cloneStrings(k.KeyOps)
+	c.X5c = cloneStrings(k.X5c)
+	c.Extensions = cloneRawMessages(k.Extensions)
+	return &c
+}
+
+func (k *JWK) MarshalJSON() ([]byte, error) {
+	if member := k.privateMember(); member != "" {
+		return nil, fmt.Errorf("invalid JWK: private key member %q", member)
+	}
+	raw, err := json.Marshal(*k)
+	if err != nil {
+		return nil, err
+	}
+	if len(k.Extensions) == 0 {
+		return raw, nil
+	}
+	m, err := jsonutils.DecodeObject(raw)
+	if err != nil {
+		return nil, err
+	}
+	jsonutils.MergeExtensions(m, k.Extensions)
+	return json.Marshal(m)
+}
+
+// PublicKey decodes the JWK into a public key, see NewJWK for the supported key types.
+func (k *JWK) PublicKey() (crypto.PublicKey, error) {
+	if err := k.Validate(); err != nil {
+		return nil, err
+	}
+	decode := func(member, value string) ([]byte, error) {
+		b, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWK: %s: %w", member, err)
+		}
+		return b, nil
+	}
+	switch k.Kty {
+	case "OKP":
+		x, err := decode("x", k.X)
+		if err != nil {
+			return nil, err
+		}
+		switch k.Crv {
+		case CurveEd25519:
+			if len(x) != ed25519.PublicKeySize {
+				return nil, fmt.Errorf("invalid Ed25519 public key: %d bytes", len(x))
+			}
+			return ed25519.PublicKey(x), nil
+		case CurveX25519:
+			return ecdh.X25519().NewPublicKey(x)
+		}
+	case "EC":
+		x, err := decode("x", k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode("y", k.Y)
+		if err != nil {
+			return nil, err
+		}
+		var curve elliptic.Curve
+		switch k.Crv {
+		case CurveSecp256k1:
+			return newSecp256k1PublicKey(new(big.Int).SetBytes(x), new(big.Int).SetBytes(y))
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported JWK curve: %s", k.Crv)
+		}
+		key := ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("invalid JWK: point is not on curve %s", k.Crv)
+		}
+		return &key, nil
+	case "RSA":
+		n, err := decode("n", k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode("e", k.E)
+		if err != nil {
+			return nil, err
+		}
+		if len(e) == 0 || 4 < len(e) {
+			return nil, fmt.Errorf("invalid JWK: invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	}
+	return nil, fmt.Errorf("unsupported JWK curve: %s", k.Crv)
+}
+
+func (k *JWK) UnmarshalJSON(raw []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return err
+	}
+	for _, member := range privateJWKMembers {
+		if _, ok := m[member]; ok {
+			return fmt.Errorf("invalid JWK: private key member %q", member)
+		}
+	}
+	if err := jsonutils.UnmarshalFields(m, []jsonutils.UnmarshalField{
+		{Key: "kty", Target: &k.Kty},
+		{Key: "use", Target: &k.Use, Optional: true},
+		{Key: "key_ops", Target: &k.KeyOps, Optional: true},
+		{Key: "alg", Target: &k.Alg, Optional: true},
+		{Key: "kid", Target: &k.Kid, Optional: true},
+		{Key: "x5u", Target: &k.X5u, Optional: true},
+		{Key: "x5c", Target: &k.X5c, Optional: true},
+		{Key: "x5t", Target: &k.X5t, Optional: true},
+		{Key: "x5t#S256", Target: &k.X5tS256, Optional: true},
+		{Key: "crv", Target: &k.Crv, Optional: true},
+		{Key: "x", Target: &k.X, Optional: true},
+		{Key: "y", Target: &k.Y, Optional: true},
+		{Key: "n", Target: &k.N, Optional: true},
+		{Key: "e", Target: &k.E, Optional: true},
+	}); err != nil {
+		return fmt.Errorf("invalid JWK: %w", err)
+	}
+	k.Extensions = jsonutils.UnknownFields(m, []string{
+		"kty", "use", "key_ops", "alg", "kid", "x5u", "x5c", "x5t", "x5t#S256", "crv", "x", "y", "n", "e",
+	})
+	return nil
+}
+
+// Validate checks that all members required by the key type are present, and that there are no private key members.
+func (k *JWK) Validate() error {
+	if member := k.privateMember(); member != "" {
+		return fmt.Errorf("invalid JWK: private key member %q", member)
+	}
+	var required map[string]string
+	switch k.Kty {
+	case "OKP":
+		required = map[string]string{"crv": k.Crv, "x": k.X}
+	case "EC":
+		required = map[string]string{"crv": k.Crv, "x": k.X, "y": k.Y}
+	case "RSA":
+		required = map[string]string{"n": k.N, "e": k.E}
+	case "":
+		return fmt.Errorf("invalid JWK: missing kty")
+	default:
+		return fmt.Errorf("unsupported JWK key type: %s", k.Kty)
+	}
+	for _, member := range []string{"crv", "x", "y", "n", "e"} {
+		if v, ok := required[member]; ok && v == "" {
+			return fmt.Errorf("invalid JWK: missing %s", member)
+		}
+	}
+	return nil
+}
+
+// privateMember returns the name of the first private key member in the extensions, if any.
+func (k *JWK) privateMember() string {
+	for _, member := range privateJWKMembers {
+		if _, ok := k.Extensions[member]; ok {
+			return member
+		}
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package did
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJWK(t *testing.T) {
+	for _, raw := range []string{
+		// SOURCE: https://www.rfc-editor.org/rfc/rfc7517#appendix-A.1
+		`{"kty":"EC","use":"enc","kid":"1","crv":"P-256","x":"MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4","y":"4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM"}`,
+		`{"kty":"RSA","alg":"RS256","kid":"2011-04-29","n":"0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw","e":"AQAB"}`,
+		// SOURCE: https://www.rfc-editor.org/rfc/rfc8037#appendix-A.2
+		`{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`,
+		// Array and numeric members.
+		`{"kty":"OKP","key_ops":["verify"],"crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo","x5c":["MIIB"],"exp":1700000000,"ext":true}`,
+	} {
+		var jwk JWK
+		if err := json.Unmarshal([]byte(raw), &jwk); err != nil {
+			t.Fatal(err)
+		}
+		if err := jwk.Validate(); err != nil {
+			t.Error(err)
+		}
+		if _, err := jwk.PublicKey(); err != nil {
+			t.Error(err)
+		}
+		out, err := json.Marshal(&jwk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equalJSON(json.RawMessage(raw), json.RawMessage(out)) {
+			t.Errorf("expected %s, got %s", raw, out)
+		}
+	}
+}
+
+func TestJWK_private(t *testing.T) {
+	// SOURCE: https://www.rfc-editor.org/rfc/rfc8037#appendix-A.1
+	raw := `{"kty":"OKP","crv":"Ed25519","d":"nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`
+	var jwk JWK
+	if err := json.Unmarshal([]byte(raw), &jwk); err == nil {
+		t.Error("expected an error")
+	}
+	if _, err := ParseDocument([]byte(`{"id": "did:example:123", "verificationMethod": [{"id": "#key-1", "type": "JsonWebKey2020", "controller": "did:example:123", "publicKeyJwk": ` + raw + `}]}`)); err == nil {
+		t.Error("expected an error")
+	}
+
+	jwk = JWK{Kty: "OKP", Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo", Extensions: map[string]json.RawMessage{"d": json.RawMessage(`"secret"`)}}
+	if err := jwk.Validate(); err == nil {
+		t.Error("expected an error")
+	}
+	if _, err := json.Marshal(&jwk); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestNewJWK(t *testing.T) {
+	edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	p521Key, _ := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	for _, key := range []any{edKey, &p521Key.PublicKey} {
+		jwk, err := NewJWK(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k, err := jwk.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(k, key) {
+			t.Errorf("expected %v, got %v", key, k)
+		}
+	}
+	if jwk, _ := NewJWK(&p521Key.PublicKey); len(jwk.X) != 88 || len(jwk.Y) != 88 {
+		t.Error("coordinates must be padded to the full length", jwk)
+	}
+}
@@ -0,0 +1,251 @@
+package did
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/0x51-dev/did/internal/multiformats"
+	"reflect"
+	"strings"
+)
+
+// ErrNoPublicKey is returned if the verification material of a verification method is not a public key, e.g. a
+// blockchain account.
+var ErrNoPublicKey = errors.New("verification material is not a public key")
+
+// BlockchainAccount is a blockchain account, identified by a CAIP-10 account ID.
+// DOCS: https://github.com/ChainAgnostic/CAIPs/blob/main/CAIPs/caip-10.md
+type BlockchainAccount struct {
+	// Namespace of the chain, e.g. "eip155".
+	Namespace string
+	// Reference of the chain within the namespace, e.g. "1". Empty if unknown, e.g. for an ethereumAddress.
+	Reference string
+	Address   string
+}
+
+// ParseBlockchainAccountID parses a CAIP-10 account ID ("namespace:reference:address"), or the legacy format
+// ("address@namespace:reference").
+func ParseBlockchainAccountID(id string) (*BlockchainAccount, error) {
+	var namespace, reference, address string
+	if a, chain, ok := strings.Cut(id, "@"); ok {
+		address = a
+		namespace, reference, _ = strings.Cut(chain, ":")
+	} else {
+		parts := strings.SplitN(id, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid blockchainAccountId: %s", id)
+		}
+		namespace, reference, address = parts[0], parts[1], parts[2]
+	}
+	account := BlockchainAccount{Namespace: namespace, Reference: reference, Address: address}
+	if !account.valid() || reference == "" {
+		return nil, fmt.Errorf("invalid blockchainAccountId: %s", id)
+	}
+	return &account, nil
+}
+
+// String returns the CAIP-10 account ID, or only the address if the chain reference is unknown.
+func (a BlockchainAccount) String() string {
+	if a.Reference == "" {
+		return a.Address
+	}
+	return fmt.Sprintf("%s:%s:%s", a.Namespace, a.Reference, a.Address)
+}
+
+func (a BlockchainAccount) valid() bool {
+	return matchCharacters(a.Namespace, 3, 8, func(c byte) bool {
+		return isLowerAlphaNumeric(c) || c == '-'
+	}) && (a.Reference == "" || matchCharacters(a.Reference, 1, 32, func(c byte) bool {
+		return isIDCharacter(c) && c != '.'
+	})) && matchCharacters(a.Address, 1, 128, func(c byte) bool {
+		return isIDCharacter(c) && c != '_' || c == '%'
+	})
+}
+
+// matchCharacters checks whether the length of s is within [minLength, maxLength] and all its characters are allowed.
+func matchCharacters(s string, minLength, maxLength int, allowed func(c byte) bool) bool {
+	if len(s) < minLength || maxLength < len(s) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !allowed(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewVerificationMethod returns a verification method for the given public key. RSA keys are encoded as JsonWebKey2020,
+// all others as Multikey. See MethodType for the supported keys.
+func NewVerificationMethod(id string, controller DID, key crypto.PublicKey) (*VerificationMethod, error) {
+	method := VerificationMethod{
+		ID:         id,
+		Controller: controller.String(),
+		Type:       Multikey,
+	}
+	if _, ok := key.(*rsa.PublicKey); ok {
+		method.Type = JsonWebKey2020
+	}
+	if err := method.setMaterial(key); err != nil {
+		return nil, err
+	}
+	return &method, nil
+}
+
+// keyCurve returns the name of the curve of the public key, or "RSA" for RSA keys.
+func keyCurve(key crypto.PublicKey) (string, error) {
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		return CurveEd25519, nil
+	case *ecdh.PublicKey:
+		if key.Curve() != ecdh.X25519() {
+			return "", fmt.Errorf("unsupported ECDH curve: %s", key.Curve())
+		}
+		return CurveX25519, nil
+	case *ecdsa.PublicKey:
+		return key.Curve.Params().Name, nil
+	case Secp256k1PublicKey:
+		return CurveSecp256k1, nil
+	case *rsa.PublicKey:
+		return "RSA", nil
+	default:
+		return "", fmt.Errorf("unsupported public key type: %T", key)
+	}
+}
+
+// publicKeyFromBytes decodes a raw public key, based on the curve of the verification method type.
+func publicKeyFromBytes(typ string, b []byte) (crypto.PublicKey, error) {
+	switch t := MethodTypes[typ]; t.Curve {
+	case CurveEd25519:
+		if len(b) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 public key: %d bytes", len(b))
+		}
+		return ed25519.PublicKey(b), nil
+	case CurveX25519:
+		return ecdh.X25519().NewPublicKey(b)
+	case CurveSecp256k1:
+		return NewSecp256k1PublicKey(b)
+	default:
+		return nil, fmt.Errorf("unsupported verification method type for raw public keys: %s", typ)
+	}
+}
+
+// publicKeyFromMulticodec decodes a multicodec prefixed public key.
+func publicKeyFromMulticodec(b []byte) (crypto.PublicKey, error) {
+	code, key, err := multiformats.DecodeMulticodec(b)
+	if err != nil {
+		return nil, err
+	}
+	switch code {
+	case multiformats.Ed25519Pub:
+		if len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 public key: %d bytes", len(key))
+		}
+		return ed25519.PublicKey(key), nil
+	case multiformats.X25519Pub:
+		return ecdh.X25519().NewPublicKey(key)
+	case multiformats.Secp256k1Pub:
+		return NewSecp256k1PublicKey(key)
+	case multiformats.P256Pub, multiformats.P384Pub:
+		curve := elliptic.P256()
+		if code == multiformats.P384Pub {
+			curve = elliptic.P384()
+		}
+		x, y := elliptic.UnmarshalCompressed(curve, key)
+		if x == nil {
+			return nil, fmt.Errorf("invalid %s public key", curve.Params().Name)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported multicodec key type: 0x%x", code)
+	}
+}
+
+// BlockchainAccount returns the blockchain account of the verification method, from either the blockchainAccountId or
+// the ethereumAddress.
+func (v *VerificationMethod) BlockchainAccount() (*BlockchainAccount, error) {
+	switch {
+	case v.BlockchainAccountID != "":
+		return ParseBlockchainAccountID(v.BlockchainAccountID)
+	case v.EthereumAddress != "":
+		if len(v.EthereumAddress) != 42 || !strings.HasPrefix(v.EthereumAddress, "0x") {
+			return nil, fmt.Errorf("invalid ethereumAddress: %s", v.EthereumAddress)
+		}
+		if _, err := hex.DecodeString(v.EthereumAddress[2:]); err != nil {
+			return nil, fmt.Errorf("invalid ethereumAddress: %s", v.EthereumAddress)
+		}
+		return &BlockchainAccount{Namespace: "eip155", Address: v.EthereumAddress}, nil
+	default:
+		return nil, fmt.Errorf("verification material is not a blockchain account")
+	}
+}
+
+// PublicKey decodes the verification material into a public key: an ed25519.PublicKey, an *ecdh.PublicKey (X25519),
+// an *ecdsa.PublicKey (P-256, P-384 or P-521), a Secp256k1PublicKey or an *rsa.PublicKey. Legacy formats
+// (publicKeyBase58 and publicKeyHex) are decoded based on the type of the verification method. ErrNoPublicKey is
+// returned for blockchain accounts.
+func (v *VerificationMethod) PublicKey() (crypto.PublicKey, error) {
+	switch {
+	case v.PublicKeyJwk != nil:
+		return v.PublicKeyJwk.PublicKey()
+	case v.PublicKeyMultibase != "":
+		_, b, err := multiformats.DecodeMultibase(v.PublicKeyMultibase)
+		if err != nil {
+			return nil, err
+		}
+		return publicKeyFromMulticodec(b)
+	case v.PublicKeyBase58 != "":
+		b, err := multiformats.DecodeBase58(v.PublicKeyBase58)
+		if err != nil {
+			return nil, fmt.Errorf("invalid publicKeyBase58: %w", err)
+		}
+		return publicKeyFromBytes(v.Type, b)
+	case v.PublicKeyHex != "":
+		b, err := hex.DecodeString(v.PublicKeyHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid publicKeyHex: %w", err)
+		}
+		return publicKeyFromBytes(v.Type, b)
+	case v.BlockchainAccountID != "" || v.EthereumAddress != "":
+		return nil, ErrNoPublicKey
+	default:
+		return nil, fmt.Errorf("missing verification material")
+	}
+}
+
+// materials returns the names of the verification material properties that are set.
+func (v *VerificationMethod) materials() []string {
+	var materials []string
+	for _, m := range []struct {
+		name string
+		set  bool
+	}{
+		{"publicKeyJwk", v.PublicKeyJwk != nil},
+		{"publicKeyMultibase", v.PublicKeyMultibase != ""},
+		{"publicKeyBase58", v.PublicKeyBase58 != ""},
+		{"publicKeyHex", v.PublicKeyHex != ""},
+		{"blockchainAccountId", v.BlockchainAccountID != ""},
+		{"ethereumAddress", v.EthereumAddress != ""},
+	} {
+		if m.set {
+			materials = append(materials, m.name)
+		}
+	}
+	return materials
+}
+
+// sameMaterial checks whether both verification methods have the same verification material.
+func (v *VerificationMethod) sameMaterial(o *VerificationMethod) bool {
+	return reflect.DeepEqual(v.PublicKeyJwk, o.PublicKeyJwk) &&
+		v.PublicKeyMultibase == o.PublicKeyMultibase &&
+		v.PublicKeyBase58 == o.PublicKeyBase58 &&
+		v.PublicKeyHex == o.PublicKeyHex &&
+		v.BlockchainAccountID == o.BlockchainAccountID &&
+		v.EthereumAddress == o.EthereumAddress
+}
@@ -0,0 +1,125 @@
+package did
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/0x51-dev/did/internal/multiformats"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVerificationMethod_PublicKey(t *testing.T) {
+	id, _ := ParseBareDID("did:example:123")
+	edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	xKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	p256Key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384Key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	for _, key := range []any{edKey, xKey.PublicKey(), &p256Key.PublicKey, &p384Key.PublicKey, &rsaKey.PublicKey} {
+		method, err := NewVerificationMethod("#key-1", *id, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k, err := method.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(k, key) {
+			t.Errorf("expected %v, got %v", key, k)
+		}
+	}
+}
+
+func TestVerificationMethod_PublicKey_legacy(t *testing.T) {
+	// SOURCE: https://www.w3.org/TR/did-spec-registries/#publickeybase58, https://www.w3.org/TR/did-spec-registries/#publickeyhex
+	raw := `{
+		"id": "did:example:123",
+		"verificationMethod": [
+			{"id": "#key-1", "type": "Ed25519VerificationKey2018", "controller": "did:example:123", "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"},
+			{"id": "#key-2", "type": "EcdsaSecp256k1VerificationKey2019", "controller": "did:example:123", "publicKeyHex": "027560af3387d375e3342a6968179ef3c6d04f5d33b2b611cf326d4708badd7770"},
+			{"id": "#key-3", "type": "X25519KeyAgreementKey2019", "controller": "did:example:123", "publicKeyBase58": "JhNWeSVLMYccCk7iopQW4guaSJTojqpMEELgSLhKwRr"},
+			{"id": "#key-4", "type": "JsonWebKey2020", "controller": "did:example:123", "publicKeyJwk": {"kty": "OKP", "crv": "Ed25519", "x": "%s"}}
+		]
+	}`
+	b, _ := multiformats.DecodeBase58("H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV")
+	doc, err := ParseDocument([]byte(fmt.Sprintf(raw, base64.RawURLEncoding.EncodeToString(b))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := doc.Validate(); v.Err() != nil {
+		t.Error(v)
+	}
+	var keys []any
+	for _, method := range doc.VerificationMethod {
+		key, err := method.PublicKey()
+		if err != nil {
+			t.Fatal(method.ID, err)
+		}
+		keys = append(keys, key)
+	}
+	if !reflect.DeepEqual(keys[0], ed25519.PublicKey(b)) || !reflect.DeepEqual(keys[3], keys[0]) {
+		t.Error(keys)
+	}
+	if _, ok := keys[1].(Secp256k1PublicKey); !ok {
+		t.Error(keys[1])
+	}
+	if _, ok := keys[2].(*ecdh.PublicKey); !ok {
+		t.Error(keys[2])
+	}
+
+	// Legacy material is preserved on marshal.
+	out, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, property := range []string{`"publicKeyBase58":"H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"`, `"publicKeyHex":"027560af3387d375e3342a6968179ef3c6d04f5d33b2b611cf326d4708badd7770"`} {
+		if !strings.Contains(string(out), property) {
+			t.Errorf("missing %s in %s", property, out)
+		}
+	}
+}
+
+func TestVerificationMethod_BlockchainAccount(t *testing.T) {
+	for _, test := range []struct {
+		method   VerificationMethod
+		expected string
+	}{
+		// SOURCE: https://www.w3.org/TR/did-spec-registries/#blockchainaccountid
+		{VerificationMethod{BlockchainAccountID: "eip155:1:0x89a932207c485f85226d86f7cd486a89a24fcc12"}, "eip155:1:0x89a932207c485f85226d86f7cd486a89a24fcc12"},
+		{VerificationMethod{BlockchainAccountID: "0x89a932207c485f85226d86f7cd486a89a24fcc12@eip155:1"}, "eip155:1:0x89a932207c485f85226d86f7cd486a89a24fcc12"},
+		{VerificationMethod{BlockchainAccountID: "bip122:000000000019d6689c085ae165831e93:128Lkh3S7CkDTBZ8W7BbpsN3YYizJMp8p6"}, "bip122:000000000019d6689c085ae165831e93:128Lkh3S7CkDTBZ8W7BbpsN3YYizJMp8p6"},
+		// SOURCE: https://www.w3.org/TR/did-spec-registries/#ethereumaddress
+		{VerificationMethod{EthereumAddress: "0xF3beAC30C498D9E26865F34fCAa57dBB935b0D74"}, "0xF3beAC30C498D9E26865F34fCAa57dBB935b0D74"},
+	} {
+		account, err := test.method.BlockchainAccount()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if account.String() != test.expected {
+			t.Error(account, test.expected)
+		}
+		if _, err := test.method.PublicKey(); !errors.Is(err, ErrNoPublicKey) {
+			t.Error(err)
+		}
+	}
+	for _, method := range []VerificationMethod{
+		{BlockchainAccountID: "eip155:0x89a932207c485f85226d86f7cd486a89a24fcc12"},
+		{BlockchainAccountID: "EIP155:1:0x89a932207c485f85226d86f7cd486a89a24fcc12"},
+		{BlockchainAccountID: "eip155:1:0x89a9_32207c485f85226d86f7cd486a89a24fcc12"},
+		{EthereumAddress: "0xF3beAC30C498D9E26865F34fCAa57dBB935b0D7"},
+		{PublicKeyMultibase: "z6Mk"},
+	} {
+		if _, err := method.BlockchainAccount(); err == nil {
+			t.Error("expected an error", method)
+		}
+	}
+}
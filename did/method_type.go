@@ -0,0 +1,257 @@
+package did
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/hex"
+	"fmt"
+	"github.com/0x51-dev/did/internal/multiformats"
+)
+
+// Verification method types.
+const (
+	// EcdsaSecp256k1RecoveryMethod2020 - a verification method with a blockchainAccountId or ethereumAddress.
+	// DOCS: https://identity.foundation/EcdsaSecp256k1RecoverySignature2020/
+	EcdsaSecp256k1RecoveryMethod2020 = "EcdsaSecp256k1RecoveryMethod2020"
+	// EcdsaSecp256k1VerificationKey2019 - a verification method with a secp256k1 publicKeyJwk (or legacy publicKeyHex).
+	// DOCS: https://w3c-ccg.github.io/lds-ecdsa-secp256k1-2019/
+	EcdsaSecp256k1VerificationKey2019 = "EcdsaSecp256k1VerificationKey2019"
+	// Ed25519VerificationKey2018 - a (legacy) verification method with an Ed25519 publicKeyBase58.
+	// DOCS: https://w3c-ccg.github.io/lds-ed25519-2018/
+	Ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	// Ed25519VerificationKey2020 - a verification method with a multicodec prefixed Ed25519 publicKeyMultibase.
+	// DOCS: https://w3c-ccg.github.io/lds-ed25519-2020/
+	Ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
+	// JsonWebKey - a verification method with a publicKeyJwk.
+	// DOCS: https://www.w3.org/TR/cid-1.0/#JsonWebKey
+	JsonWebKey = "JsonWebKey"
+	// JsonWebKey2020 - a verification method with a publicKeyJwk, the predecessor of JsonWebKey.
+	// DOCS: https://w3c-ccg.github.io/lds-jws2020/#json-web-key-2020
+	JsonWebKey2020 = "JsonWebKey2020"
+	// Multikey - a verification method with a publicKeyMultibase, prefixed with its multicodec key type.
+	// DOCS: https://www.w3.org/TR/cid-1.0/#Multikey
+	Multikey = "Multikey"
+	// X25519KeyAgreementKey2019 - a (legacy) verification method with an X25519 publicKeyBase58.
+	// DOCS: https://w3c-ccg.github.io/ldp-x25519-2019/
+	X25519KeyAgreementKey2019 = "X25519KeyAgreementKey2019"
+	// X25519KeyAgreementKey2020 - a verification method with a multicodec prefixed X25519 publicKeyMultibase.
+	// DOCS: https://w3c-ccg.github.io/lds-x25519-2020/
+	X25519KeyAgreementKey2020 = "X25519KeyAgreementKey2020"
+)
+
+// Curves, named as in JSON Web Keys.
+// DOCS: https://www.iana.org/assignments/jose/jose.xhtml#web-key-elliptic-curve
+const (
+	CurveEd25519   = "Ed25519"
+	CurveSecp256k1 = "secp256k1"
+	CurveX25519    = "X25519"
+)
+
+// contextCID is the context of the Controlled Identifiers specification, which defines Multikey and JsonWebKey.
+const contextCID = "https://www.w3.org/ns/cid/v1"
+
+// MethodType describes a verification method type.
+type MethodType struct {
+	// Contexts are the JSON-LD contexts that define the type, any of them suffices. The first one is preferred.
+	Contexts []string
+	// Materials are the verification material properties allowed by the type. The first one is preferred.
+	Materials []string
+	// Curve restricts the type to keys of the given curve, if not empty.
+	Curve string
+}
+
+// MethodTypes are the known verification method types, registered in the DID specification registries. Methods of
+// other types are not validated against their type.
+// DOCS: https://www.w3.org/TR/did-spec-registries/#verification-method-types
+var MethodTypes = map[string]MethodType{
+	EcdsaSecp256k1RecoveryMethod2020: {
+		Contexts:  []string{"https://w3id.org/security/suites/secp256k1recovery-2020/v2"},
+		Materials: []string{"blockchainAccountId", "ethereumAddress", "publicKeyJwk", "publicKeyHex"},
+		Curve:     CurveSecp256k1,
+	},
+	EcdsaSecp256k1VerificationKey2019: {
+		Contexts:  []string{"https://w3id.org/security/suites/secp256k1-2019/v1"},
+		Materials: []string{"publicKeyJwk", "publicKeyHex", "publicKeyBase58"},
+		Curve:     CurveSecp256k1,
+	},
+	Ed25519VerificationKey2018: {
+		Contexts:  []string{"https://w3id.org/security/suites/ed25519-2018/v1"},
+		Materials: []string{"publicKeyBase58"},
+		Curve:     CurveEd25519,
+	},
+	Ed25519VerificationKey2020: {
+		Contexts:  []string{"https://w3id.org/security/suites/ed25519-2020/v1"},
+		Materials: []string{"publicKeyMultibase"},
+		Curve:     CurveEd25519,
+	},
+	JsonWebKey: {
+		Contexts:  []string{"https://w3id.org/security/jwk/v1", ContextV11, contextCID},
+		Materials: []string{"publicKeyJwk"},
+	},
+	JsonWebKey2020: {
+		Contexts:  []string{"https://w3id.org/security/suites/jws-2020/v1"},
+		Materials: []string{"publicKeyJwk"},
+	},
+	Multikey: {
+		Contexts:  []string{"https://w3id.org/security/multikey/v1", ContextV11, contextCID},
+		Materials: []string{"publicKeyMultibase"},
+	},
+	X25519KeyAgreementKey2019: {
+		Contexts:  []string{"https://w3id.org/security/suites/x25519-2019/v1"},
+		Materials: []string{"publicKeyBase58"},
+		Curve:     CurveX25519,
+	},
+	X25519KeyAgreementKey2020: {
+		Contexts:  []string{"https://w3id.org/security/suites/x25519-2020/v1"},
+		Materials: []string{"publicKeyMultibase"},
+		Curve:     CurveX25519,
+	},
+}
+
+// multicodecs are the multicodec codes of the keys, by their curve.
+var multicodecs = map[string]uint64{
+	CurveEd25519:   multiformats.Ed25519Pub,
+	CurveSecp256k1: multiformats.Secp256k1Pub,
+	CurveX25519:    multiformats.X25519Pub,
+	"P-256":        multiformats.P256Pub,
+	"P-384":        multiformats.P384Pub,
+}
+
+// rawPublicKey returns the raw bytes of the public key, elliptic curve points are compressed.
+func rawPublicKey(key crypto.PublicKey) ([]byte, error) {
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		return key, nil
+	case *ecdh.PublicKey:
+		return key.Bytes(), nil
+	case *ecdsa.PublicKey:
+		return elliptic.MarshalCompressed(key.Curve, key.X, key.Y), nil
+	case Secp256k1PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type for raw encoding: %T", key)
+	}
+}
+
+// Convert returns a copy of the verification method of the given type, with the same key encoded in the preferred
+// verification material of that type, e.g. an Ed25519VerificationKey2018 to a Multikey or a JsonWebKey.
+func (v *VerificationMethod) Convert(typ string) (*VerificationMethod, error) {
+	key, err := v.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	c := v.Clone()
+	c.Type = typ
+	if err := c.setMaterial(key); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// setMaterial replaces the verification material with the given key, encoded in the preferred verification material
+// of the type of the method.
+func (v *VerificationMethod) setMaterial(key crypto.PublicKey) error {
+	t, ok := MethodTypes[v.Type]
+	if !ok {
+		return fmt.Errorf("unknown verification method type: %s", v.Type)
+	}
+	curve, err := keyCurve(key)
+	if err != nil {
+		return err
+	}
+	if t.Curve != "" && t.Curve != curve {
+		return fmt.Errorf("%s does not support %s keys", v.Type, curve)
+	}
+
+	m := VerificationMethod{ID: v.ID, Controller: v.Controller, Type: v.Type, Extensions: v.Extensions}
+	switch t.Materials[0] {
+	case "publicKeyJwk":
+		if m.PublicKeyJwk, err = NewJWK(key); err != nil {
+			return err
+		}
+	case "publicKeyMultibase":
+		code, ok := multicodecs[curve]
+		if !ok {
+			return fmt.Errorf("unsupported %s key for publicKeyMultibase", curve)
+		}
+		raw, err := rawPublicKey(key)
+		if err != nil {
+			return err
+		}
+		if m.PublicKeyMultibase, err = multiformats.EncodeMultibase(multiformats.Base58BTC, multiformats.EncodeMulticodec(code, raw)); err != nil {
+			return err
+		}
+	case "publicKeyBase58":
+		raw, err := rawPublicKey(key)
+		if err != nil {
+			return err
+		}
+		m.PublicKeyBase58 = multiformats.EncodeBase58(raw)
+	case "publicKeyHex":
+		raw, err := rawPublicKey(key)
+		if err != nil {
+			return err
+		}
+		m.PublicKeyHex = hex.EncodeToString(raw)
+	default:
+		return fmt.Errorf("can not encode %s keys as %s", curve, t.Materials[0])
+	}
+	*v = m
+	return nil
+}
+
+// validateMethodType validates the verification method against its type, if known.
+func (d *Document) validateMethodType(v *Violations, path string, method *VerificationMethod) {
+	t, ok := MethodTypes[method.Type]
+	if !ok {
+		return
+	}
+	if 0 < len(d.Context) && !containsAny(contextURLs(d.Context), t.Contexts) {
+		v.add(path+".type", SeverityWarning, "%s requires the @context %s", method.Type, t.Contexts[0])
+	}
+	materials := method.materials()
+	if len(materials) != 1 {
+		return
+	}
+	if !containsAny(t.Materials, materials) {
+		v.add(path+"."+materials[0], SeverityError, "%s does not allow %s", method.Type, materials[0])
+		return
+	}
+	key, err := method.PublicKey()
+	if err != nil {
+		if err != ErrNoPublicKey {
+			v.add(path+"."+materials[0], SeverityWarning, "can not decode the key: %v", err)
+		}
+		return
+	}
+	if curve, err := keyCurve(key); err == nil && t.Curve != "" && t.Curve != curve {
+		v.add(path+"."+materials[0], SeverityError, "%s does not allow %s keys", method.Type, curve)
+	}
+}
+
+// defaultContextURLs returns the URLs of the contexts of a document with the given verification methods: the context of
+// the DID specification, followed by the first context of each known method type of which no context is included yet.
+func defaultContextURLs(methods VerificationMethods) []string {
+	urls := []string{ContextV1}
+	for _, method := range methods {
+		if t, ok := MethodTypes[method.Type]; ok && !containsAny(urls, t.Contexts) {
+			urls = append(urls, t.Contexts[0])
+		}
+	}
+	return urls
+}
+
+// containsAny checks whether any of the values is contained in the slice.
+func containsAny(slice []string, values []string) bool {
+	for _, s := range slice {
+		for _, v := range values {
+			if s == v {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package did
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+)
+
+func TestVerificationMethod_Convert(t *testing.T) {
+	for _, test := range []struct {
+		method VerificationMethod
+		types  []string
+		prefix string
+	}{
+		{
+			// SOURCE: https://www.w3.org/TR/did-spec-registries/#publickeybase58
+			method: VerificationMethod{ID: "#key-1", Type: Ed25519VerificationKey2018, Controller: "did:example:123", PublicKeyBase58: "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"},
+			types:  []string{Multikey, JsonWebKey, Ed25519VerificationKey2020, JsonWebKey2020, Ed25519VerificationKey2018},
+			prefix: "z6Mk",
+		},
+		{
+			// SOURCE: https://www.w3.org/TR/did-spec-registries/#publickeyhex
+			method: VerificationMethod{ID: "#key-1", Type: EcdsaSecp256k1VerificationKey2019, Controller: "did:example:123", PublicKeyHex: "027560af3387d375e3342a6968179ef3c6d04f5d33b2b611cf326d4708badd7770"},
+			types:  []string{Multikey, JsonWebKey, EcdsaSecp256k1VerificationKey2019, Multikey},
+			prefix: "zQ3s",
+		},
+	} {
+		key, err := test.method.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		method := &test.method
+		for _, typ := range test.types {
+			if method, err = method.Convert(typ); err != nil {
+				t.Fatal(typ, err)
+			}
+			if method.Type != typ || len(method.materials()) != 1 || method.ID != "#key-1" {
+				t.Error(method)
+			}
+			if typ == Multikey && !strings.HasPrefix(method.PublicKeyMultibase, test.prefix) {
+				t.Error(method.PublicKeyMultibase)
+			}
+			k, err := method.PublicKey()
+			if err != nil {
+				t.Fatal(typ, err)
+			}
+			if !key.(interface{ Equal(crypto.PublicKey) bool }).Equal(k) {
+				t.Errorf("%s: expected %v, got %v", typ, key, k)
+			}
+		}
+		if method.sameMaterial(&test.method) != (method.Type == test.method.Type) {
+			t.Error(method, test.method)
+		}
+	}
+
+	method := VerificationMethod{ID: "#key-1", Type: Ed25519VerificationKey2018, Controller: "did:example:123", PublicKeyBase58: "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"}
+	for _, typ := range []string{X25519KeyAgreementKey2020, EcdsaSecp256k1RecoveryMethod2020, "UnknownKey2024"} {
+		if _, err := method.Convert(typ); err == nil {
+			t.Error("expected an error", typ)
+		}
+	}
+}
+
+func TestDocument_Validate_methodType(t *testing.T) {
+	p256, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	id, _ := ParseBareDID("did:example:123")
+	m, err := NewVerificationMethod("#key-1", *id, &p256.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		context  string
+		method   VerificationMethod
+		errors   []string
+		warnings []string
+	}{
+		{context: ContextV11, method: *m},
+		{context: ContextV1, method: *m, warnings: []string{"$.verificationMethod[0].type"}},
+		{
+			context: ContextV1 + `", "https://w3id.org/security/suites/ed25519-2020/v1`,
+			method:  VerificationMethod{ID: "#key-1", Type: Ed25519VerificationKey2020, Controller: "did:example:123", PublicKeyMultibase: m.PublicKeyMultibase},
+			errors:  []string{"$.verificationMethod[0].publicKeyMultibase"},
+		},
+		{
+			context: ContextV11,
+			method:  VerificationMethod{ID: "#key-1", Type: Multikey, Controller: "did:example:123", PublicKeyBase58: "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"},
+			errors:  []string{"$.verificationMethod[0].publicKeyBase58"},
+		},
+		{
+			context:  ContextV11,
+			method:   VerificationMethod{ID: "#key-1", Type: Multikey, Controller: "did:example:123", PublicKeyMultibase: "z6Mk"},
+			warnings: []string{"$.verificationMethod[0].publicKeyMultibase"},
+		},
+	} {
+		doc := Document{Context: NewContexts(strings.Split(test.context, `", "`)...), ID: *id, VerificationMethod: VerificationMethods{test.method}}
+		var errors, warnings []string
+		for _, v := range doc.Validate() {
+			if v.Severity == SeverityError {
+				errors = append(errors, v.Path)
+			} else {
+				warnings = append(warnings, v.Path)
+			}
+		}
+		if strings.Join(errors, ",") != strings.Join(test.errors, ",") || strings.Join(warnings, ",") != strings.Join(test.warnings, ",") {
+			t.Error(test.method.Type, doc.Validate())
+		}
+	}
+}
+
+func TestSecp256k1PublicKey(t *testing.T) {
+	key, err := NewSecp256k1PublicKey([]byte{0x02, 0x75, 0x60, 0xaf, 0x33, 0x87, 0xd3, 0x75, 0xe3, 0x34, 0x2a, 0x69, 0x68, 0x17, 0x9e, 0xf3, 0xc6, 0xd0, 0x4f, 0x5d, 0x33, 0xb2, 0xb6, 0x11, 0xcf, 0x32, 0x6d, 0x47, 0x08, 0xba, 0xdd, 0x77, 0x70})
+	if err != nil {
+		t.Fatal(err)
+	}
+	uncompressed := key.Uncompressed()
+	k, err := NewSecp256k1PublicKey(uncompressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !k.Equal(key) {
+		t.Error(k, key)
+	}
+	uncompressed[64] ^= 1
+	if _, err := NewSecp256k1PublicKey(uncompressed); err == nil {
+		t.Error("expected an error")
+	}
+}
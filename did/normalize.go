@@ -0,0 +1,144 @@
+package did
+
+import (
+	"sort"
+	"strings"
+)
+
+// equalIDs checks whether the two (absolute or relative) DID URLs are equivalent. If either of them is not a valid DID
+// URL, they are compared as is.
+func equalIDs(a, b string) bool {
+	if a == b {
+		return true
+	}
+	u, err := ParseDIDURL(a)
+	if err != nil {
+		return false
+	}
+	v, err := ParseDIDURL(b)
+	if err != nil {
+		return false
+	}
+	return u.Equal(*v)
+}
+
+func isUnreserved(c byte) bool {
+	return isIDCharacter(c) || c == '~'
+}
+
+// normalizePercentEncoding uppercases the hexadecimal digits of all percent-encoded characters and decodes the ones for
+// which decode returns true.
+// DOCS: https://www.rfc-editor.org/rfc/rfc3986#section-6.2.2
+func normalizePercentEncoding(s string, decode func(c byte) bool) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) && isHexadecimal(s[i+1]) && isHexadecimal(s[i+2]) {
+			c := unhex(s[i+1])<<4 | unhex(s[i+2])
+			if decode(c) {
+				sb.WriteByte(c)
+			} else {
+				sb.WriteByte('%')
+				sb.WriteString(strings.ToUpper(s[i+1 : i+3]))
+			}
+			i += 2
+			continue
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// removeDotSegments removes the "." and ".." segments from the path.
+// DOCS: https://www.rfc-editor.org/rfc/rfc3986#section-5.2.4
+func removeDotSegments(path string) string {
+	if !strings.Contains(path, ".") {
+		return path
+	}
+	var output []string
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch segment {
+		case ".":
+			if i == len(segments)-1 {
+				output = append(output, "")
+			}
+		case "..":
+			if 1 < len(output) {
+				output = output[:len(output)-1]
+			}
+			if i == len(segments)-1 {
+				output = append(output, "")
+			}
+		default:
+			output = append(output, segment)
+		}
+	}
+	return strings.Join(output, "/")
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
+
+// Equal checks whether the DID is equivalent to the given one, i.e. whether both have the same normal form.
+func (d DID) Equal(o DID) bool {
+	return d.Normalize().String() == o.Normalize().String()
+}
+
+// Normalize returns the DID in its canonical form: percent-encodings use uppercase hexadecimal digits and unreserved
+// characters are decoded.
+func (d DID) Normalize() DID {
+	return d.URL().Normalize().DID()
+}
+
+// Equal checks whether the DID URL is equivalent to the given one, i.e. whether both have the same normal form.
+// DOCS: https://www.w3.org/TR/did-core/#did-syntax
+func (d DIDURL) Equal(o DIDURL) bool {
+	return d.Normalize().String() == o.Normalize().String()
+}
+
+// Normalize returns the DID URL in its canonical form: percent-encodings use uppercase hexadecimal digits and
+// unreserved characters are decoded, dot segments are removed from the path and the parameters are ordered by key.
+func (d DIDURL) Normalize() DIDURL {
+	n := DIDURL{
+		Method:   d.Method,
+		Path:     removeDotSegments(normalizePercentEncoding(d.Path, isUnreserved)),
+		Fragment: normalizePercentEncoding(d.Fragment, isUnreserved),
+	}
+	for _, id := range d.MethodIDs {
+		n.MethodIDs = append(n.MethodIDs, normalizePercentEncoding(id, isIDCharacter))
+	}
+	for _, p := range d.Parameters {
+		n.Parameters = append(n.Parameters, Parameter{
+			Key:   normalizePercentEncoding(p.Key, isIDCharacter),
+			Value: normalizePercentEncoding(p.Value, isIDCharacter),
+		})
+	}
+	sort.SliceStable(n.Parameters, func(i, j int) bool {
+		return n.Parameters[i].Key < n.Parameters[j].Key
+	})
+
+	var query []string
+	for _, kv := range strings.Split(d.Query, "&") {
+		if kv != "" {
+			query = append(query, normalizePercentEncoding(kv, isUnreserved))
+		}
+	}
+	sort.SliceStable(query, func(i, j int) bool {
+		k, _, _ := strings.Cut(query[i], "=")
+		l, _, _ := strings.Cut(query[j], "=")
+		return k < l
+	})
+	n.Query = strings.Join(query, "&")
+	return n
+}
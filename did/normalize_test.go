@@ -0,0 +1,63 @@
+package did_test
+
+import (
+	"github.com/0x51-dev/did/did"
+	"testing"
+)
+
+func TestDIDURL_Equal(t *testing.T) {
+	for _, test := range []struct {
+		a, b  string
+		equal bool
+	}{
+		{"did:example:123", "did:example:123", true},
+		{"did:web:localhost%3a8443", "did:web:localhost%3A8443", true},
+		{"did:example:%41bc", "did:example:Abc", true},
+		{"did:example:123;a=1;b=2", "did:example:123;b=2;a=1", true},
+		{"did:example:123?service=agent&versionId=1", "did:example:123?versionId=1&service=agent", true},
+		{"did:example:123?", "did:example:123", true},
+		{"did:example:123/a/./b/../c", "did:example:123/a/c", true},
+		{"did:example:123#%6Bey-1", "did:example:123#key-1", true},
+		{"did:example:123", "did:example:1234", false},
+		{"did:example:123", "did:example:123#key-1", false},
+		{"did:example:abc", "did:example:ABC", false},
+		{"did:web:localhost%3A8443", "did:web:localhost%3A8444", false},
+		{"did:example:123?a=1&a=2", "did:example:123?a=2&a=1", false},
+	} {
+		t.Run(test.a, func(t *testing.T) {
+			a, err := did.ParseDIDURL(test.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := did.ParseDIDURL(test.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a.Equal(*b) != test.equal {
+				t.Errorf("%s == %s: expected %t", test.a, test.b, test.equal)
+			}
+			if b.Equal(*a) != test.equal {
+				t.Errorf("%s == %s: expected %t", test.b, test.a, test.equal)
+			}
+		})
+	}
+}
+
+func TestDIDURL_Normalize(t *testing.T) {
+	for _, test := range []struct {
+		didURL     string
+		normalized string
+	}{
+		{"did:web:localhost%3a8443", "did:web:localhost%3A8443"},
+		{"did:example:123;b=%2f;a=1/%7euser/../x?q=%2a#f", "did:example:123;a=1;b=%2F/x?q=%2A#f"},
+	} {
+		u, err := did.ParseDIDURL(test.didURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := u.Normalize()
+		if s := n.String(); s != test.normalized {
+			t.Error(s, test.normalized)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package did
+
+import (
+	"fmt"
+	"github.com/0x51-dev/did/internal/multiformats"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DID parameters registered in the DID specification.
+// DOCS: https://www.w3.org/TR/did-core/#did-parameters
+const (
+	// HashlinkParameter - A resource hash of the DID document to add integrity protection.
+	HashlinkParameter = "hl"
+	// RelativeRefParameter - A relative URI reference that identifies a resource at a service endpoint.
+	RelativeRefParameter = "relativeRef"
+	// ServiceParameter - Identifies a service from the DID document by service ID.
+	ServiceParameter = "service"
+	// VersionIDParameter - Identifies a specific version of a DID document to be resolved.
+	VersionIDParameter = "versionId"
+	// VersionTimeParameter - Identifies a certain version timestamp of a DID document to be resolved.
+	VersionTimeParameter = "versionTime"
+)
+
+// versionTimeLayout is an RFC 3339 timestamp, normalized to UTC and without sub-second precision.
+const versionTimeLayout = "2006-01-02T15:04:05Z"
+
+// encodeQueryComponent percent-encodes a key or value of a query parameter.
+func encodeQueryComponent(s string) string {
+	return percentEncode(s, func(c byte) bool {
+		return isFragmentCharacter(c) && c != '&' && c != '=' && c != '+'
+	})
+}
+
+// validateHashlink checks that the value is a hashlink: a multibase encoded multihash, optionally followed by multibase
+// encoded metadata.
+// DOCS: https://datatracker.ietf.org/doc/html/draft-sporny-hashlink
+func validateHashlink(hl string) error {
+	hash, metadata, hasMetadata := strings.Cut(hl, ":")
+	_, b, err := multiformats.DecodeMultibase(hash)
+	if err != nil {
+		return fmt.Errorf("invalid hl: %w", err)
+	}
+	if _, _, err := multiformats.DecodeMultihash(b); err != nil {
+		return fmt.Errorf("invalid hl: %w", err)
+	}
+	if hasMetadata {
+		if _, _, err := multiformats.DecodeMultibase(metadata); err != nil {
+			return fmt.Errorf("invalid hl metadata: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateRelativeRef(relativeRef string) error {
+	u, err := url.Parse(relativeRef)
+	if err != nil {
+		return fmt.Errorf("invalid relativeRef: %w", err)
+	}
+	if u.IsAbs() {
+		return fmt.Errorf("invalid relativeRef: %s is not a relative reference", relativeRef)
+	}
+	return nil
+}
+
+func validateVersionTime(versionTime string) error {
+	if _, err := time.Parse(versionTimeLayout, versionTime); err != nil {
+		return fmt.Errorf("invalid versionTime: %s is not a UTC timestamp (%s)", versionTime, versionTimeLayout)
+	}
+	return nil
+}
+
+// Hashlink returns the validated hl parameter, or an empty string if it is absent.
+func (d DIDURL) Hashlink() (string, error) {
+	hl, ok := d.Parameter(HashlinkParameter)
+	if !ok {
+		return "", nil
+	}
+	if err := validateHashlink(hl); err != nil {
+		return "", err
+	}
+	return hl, nil
+}
+
+// Parameter returns the percent-decoded value of the DID parameter with the given key. Parameters in the query take
+// precedence over the (legacy) matrix parameters.
+func (d DIDURL) Parameter(key string) (string, bool) {
+	if v, ok := d.QueryParameters().Get(key); ok {
+		return v, true
+	}
+	v, ok := getParameter(d.Parameters, key)
+	if !ok {
+		return "", false
+	}
+	if dv, err := url.PathUnescape(v); err == nil {
+		v = dv
+	}
+	return v, true
+}
+
+// RelativeRef returns the relativeRef parameter, or an empty string if it is absent.
+func (d DIDURL) RelativeRef() string {
+	v, _ := d.Parameter(RelativeRefParameter)
+	return v
+}
+
+// Service returns the service parameter, or an empty string if it is absent.
+func (d DIDURL) Service() string {
+	v, _ := d.Parameter(ServiceParameter)
+	return v
+}
+
+// SetHashlink sets the hl parameter.
+func (d *DIDURL) SetHashlink(hl string) error {
+	if err := validateHashlink(hl); err != nil {
+		return err
+	}
+	d.SetParameter(HashlinkParameter, hl)
+	return nil
+}
+
+// SetParameter sets the DID parameter with the given key in the query, replacing any existing values of that parameter.
+func (d *DIDURL) SetParameter(key, value string) {
+	var parameters []Parameter
+	for _, p := range d.Parameters {
+		if p.Key != key {
+			parameters = append(parameters, p)
+		}
+	}
+	d.Parameters = parameters
+
+	query := d.QueryParameters()
+	query.Set(key, value)
+	d.SetQuery(query)
+}
+
+// SetRelativeRef sets the relativeRef parameter.
+func (d *DIDURL) SetRelativeRef(relativeRef string) error {
+	if err := validateRelativeRef(relativeRef); err != nil {
+		return err
+	}
+	d.SetParameter(RelativeRefParameter, relativeRef)
+	return nil
+}
+
+// SetService sets the service parameter.
+func (d *DIDURL) SetService(service string) {
+	d.SetParameter(ServiceParameter, service)
+}
+
+// SetVersionID sets the versionId parameter.
+func (d *DIDURL) SetVersionID(versionID string) {
+	d.SetParameter(VersionIDParameter, versionID)
+}
+
+// SetVersionTime sets the versionTime parameter, normalized to UTC and truncated to seconds.
+func (d *DIDURL) SetVersionTime(versionTime time.Time) {
+	d.SetParameter(VersionTimeParameter, versionTime.UTC().Format(versionTimeLayout))
+}
+
+// ValidateParameters validates the values of all DID parameters registered in the DID specification.
+func (d DIDURL) ValidateParameters() error {
+	if v, ok := d.Parameter(RelativeRefParameter); ok {
+		if err := validateRelativeRef(v); err != nil {
+			return err
+		}
+		if _, ok := d.Parameter(ServiceParameter); !ok {
+			return fmt.Errorf("invalid relativeRef: requires the service parameter")
+		}
+	}
+	if v, ok := d.Parameter(VersionTimeParameter); ok {
+		if err := validateVersionTime(v); err != nil {
+			return err
+		}
+	}
+	if _, err := d.Hashlink(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// VersionID returns the versionId parameter, or an empty string if it is absent.
+func (d DIDURL) VersionID() string {
+	v, _ := d.Parameter(VersionIDParameter)
+	return v
+}
+
+// VersionTime returns the versionTime parameter, or the zero time if it is absent.
+func (d DIDURL) VersionTime() (time.Time, error) {
+	v, ok := d.Parameter(VersionTimeParameter)
+	if !ok {
+		return time.Time{}, nil
+	}
+	if err := validateVersionTime(v); err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(versionTimeLayout, v)
+}
@@ -0,0 +1,92 @@
+package did_test
+
+import (
+	"fmt"
+	"github.com/0x51-dev/did/did"
+	"testing"
+	"time"
+)
+
+func ExampleDIDURL_SetParameter() {
+	u, _ := did.ParseDIDURL("did:example:123?service=agent#key-1")
+	u.SetService("files")
+	_ = u.SetRelativeRef("/resume.pdf")
+	u.SetVersionTime(time.Date(2021, 5, 10, 19, 0, 0, 0, time.FixedZone("CEST", 2*60*60)))
+	fmt.Println(u.String())
+	fmt.Println(u.Service(), u.RelativeRef())
+	// Output:
+	// did:example:123?service=files&relativeRef=/resume.pdf&versionTime=2021-05-10T17:00:00Z#key-1
+	// files /resume.pdf
+}
+
+func TestDIDURL_Parameter(t *testing.T) {
+	for _, test := range []struct {
+		didURL      string
+		service     string
+		relativeRef string
+		versionID   string
+	}{
+		{"did:example:123?service=agent&relativeRef=/credentials#degree", "agent", "/credentials", ""},
+		{"did:example:123?service=files&relativeRef=%2Fresume%20.pdf", "files", "/resume .pdf", ""},
+		{"did:example:123;service=agent?versionId=1", "agent", "", "1"},
+		{"did:example:123;service=agent?service=files", "files", "", ""},
+	} {
+		t.Run(test.didURL, func(t *testing.T) {
+			u, err := did.ParseDIDURL(test.didURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := u.ValidateParameters(); err != nil {
+				t.Error(err)
+			}
+			if v := u.Service(); v != test.service {
+				t.Error(v, test.service)
+			}
+			if v := u.RelativeRef(); v != test.relativeRef {
+				t.Error(v, test.relativeRef)
+			}
+			if v := u.VersionID(); v != test.versionID {
+				t.Error(v, test.versionID)
+			}
+		})
+	}
+}
+
+func TestDIDURL_ValidateParameters(t *testing.T) {
+	for _, test := range []string{
+		"did:example:123?versionTime=2021-05-10",
+		"did:example:123?versionTime=2021-05-10T17:00:00%2B02:00",
+		"did:example:123?relativeRef=/resume.pdf",
+		"did:example:123?service=files&relativeRef=https://example.com",
+		"did:example:123?hl=zInvalid",
+		"did:example:123?hl=zQmWvQxTqbG2Z9HPJgG57jjwR154cKhbtJenbyYTWkjgF3",
+	} {
+		t.Run(test, func(t *testing.T) {
+			u, err := did.ParseDIDURL(test)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := u.ValidateParameters(); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestDIDURL_VersionTime(t *testing.T) {
+	u, _ := did.ParseDIDURL("did:example:123?versionTime=2021-05-10T17:00:00Z&hl=zQmWvQxTqbG2Z9HPJgG57jjwR154cKhbtJenbyYTWkjgF3e")
+	v, err := u.VersionTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Equal(time.Date(2021, 5, 10, 17, 0, 0, 0, time.UTC)) {
+		t.Error(v)
+	}
+	hl, err := u.Hashlink()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hl != "zQmWvQxTqbG2Z9HPJgG57jjwR154cKhbtJenbyYTWkjgF3e" {
+		t.Error(hl)
+	}
+}
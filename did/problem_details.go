@@ -0,0 +1,138 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// errorTypePrefix is the namespace of the problem types of the error codes.
+const errorTypePrefix = "https://www.w3.org/ns/did#"
+
+// ProblemDetails describe a resolution (or dereferencing) error. The cause is kept to distinguish e.g. an unreachable
+// server from a malformed DID document, it is only serialized as the detail of the problem.
+// DOCS: https://www.rfc-editor.org/rfc/rfc9457
+type ProblemDetails struct {
+	// The error code, serialized as problem type URI.
+	Code Error
+	// A short, human-readable summary of the problem type.
+	Title string
+	// A human-readable explanation of this occurrence of the problem, defaults to the message of the cause.
+	Detail string
+	// The underlying cause, if any.
+	Cause error
+}
+
+// NewProblemDetails returns the problem details of the error code, caused by the given error (which may be nil).
+func NewProblemDetails(code Error, cause error) *ProblemDetails {
+	p := ProblemDetails{Code: code, Cause: cause}
+	if cause != nil {
+		p.Detail = cause.Error()
+	}
+	return &p
+}
+
+// NewErrorResult returns the result of a failed resolution, with the given error code and cause (which may be nil).
+func NewErrorResult(code Error, cause error) ResolutionResult {
+	metadata := Metadata{Error: code}
+	if cause != nil {
+		metadata.ProblemDetails = NewProblemDetails(code, cause)
+	}
+	return ResolutionResult{Metadata: metadata}
+}
+
+// Type returns the problem type URI of the error code, e.g. https://www.w3.org/ns/did#NOT_FOUND for notFound. Codes
+// that are URIs themselves are returned as is.
+func (e Error) Type() string {
+	if strings.Contains(string(e), ":") {
+		return string(e)
+	}
+	var sb strings.Builder
+	sb.WriteString(errorTypePrefix)
+	for i, c := range e {
+		if unicode.IsUpper(c) && i != 0 {
+			sb.WriteRune('_')
+		}
+		sb.WriteRune(unicode.ToUpper(c))
+	}
+	return sb.String()
+}
+
+// errorFromType returns the error code of the problem type URI, e.g. notFound for https://www.w3.org/ns/did#NOT_FOUND.
+// Problem types outside the DID namespace are kept as is.
+func errorFromType(typ string) Error {
+	name, ok := strings.CutPrefix(typ, errorTypePrefix)
+	if !ok {
+		return Error(typ)
+	}
+	var sb strings.Builder
+	upper := false
+	for _, c := range name {
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			sb.WriteRune(c)
+		} else {
+			sb.WriteRune(unicode.ToLower(c))
+		}
+		upper = false
+	}
+	return Error(sb.String())
+}
+
+// resolutionErr returns the problem details if present, otherwise the error code (if any).
+func resolutionErr(code Error, problem *ProblemDetails) error {
+	if problem != nil {
+		return problem
+	}
+	if code != "" {
+		return code
+	}
+	return nil
+}
+
+func (p *ProblemDetails) Error() string {
+	if p.Detail == "" {
+		return string(p.Code)
+	}
+	return fmt.Sprintf("%s: %s", p.Code, p.Detail)
+}
+
+func (p *ProblemDetails) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type   string `json:"type"`
+		Title  string `json:"title,omitempty"`
+		Detail string `json:"detail,omitempty"`
+	}{
+		Type:   p.Code.Type(),
+		Title:  p.Title,
+		Detail: p.Detail,
+	})
+}
+
+// Unwrap returns the error code and the cause, so both can be matched with errors.Is and errors.As.
+func (p *ProblemDetails) Unwrap() []error {
+	if p.Cause == nil {
+		return []error{p.Code}
+	}
+	return []error{p.Code, p.Cause}
+}
+
+func (p *ProblemDetails) UnmarshalJSON(raw []byte) error {
+	var v struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+	if v.Type == "" {
+		return fmt.Errorf("invalid problem details: missing type")
+	}
+	*p = ProblemDetails{Code: errorFromType(v.Type), Title: v.Title, Detail: v.Detail}
+	return nil
+}
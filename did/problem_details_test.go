@@ -0,0 +1,89 @@
+package did
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestError_Type(t *testing.T) {
+	for _, test := range []struct {
+		code Error
+		typ  string
+	}{
+		{InvalidDIDError, "https://www.w3.org/ns/did#INVALID_DID"},
+		{InvalidDIDURLError, "https://www.w3.org/ns/did#INVALID_DID_URL"},
+		{NotFoundError, "https://www.w3.org/ns/did#NOT_FOUND"},
+		{UnsupportedPublicKeyTypeError, "https://www.w3.org/ns/did#UNSUPPORTED_PUBLIC_KEY_TYPE"},
+		{"https://example.com/errors#quota", "https://example.com/errors#quota"},
+	} {
+		if typ := test.code.Type(); typ != test.typ {
+			t.Error(typ, test.typ)
+		}
+		if code := errorFromType(test.typ); code != test.code {
+			t.Error(code, test.code)
+		}
+	}
+}
+
+func TestProblemDetails(t *testing.T) {
+	cause := &ParseError{Input: "did:example", Production: "did"}
+	err := error(NewProblemDetails(InvalidDIDError, fmt.Errorf("resolve: %w", cause)))
+	if !errors.Is(err, InvalidDIDError) || errors.Is(err, NotFoundError) {
+		t.Error(err)
+	}
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr != cause {
+		t.Error(err)
+	}
+
+	raw, err := json.Marshal(err)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var problem ProblemDetails
+	if err := json.Unmarshal(raw, &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Code != InvalidDIDError || problem.Detail == "" || problem.Cause != nil {
+		t.Error(problem)
+	}
+	if err := json.Unmarshal([]byte(`{"title": "Not Found"}`), &problem); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestMetadata_Err(t *testing.T) {
+	if err := (Metadata{}).Err(); err != nil {
+		t.Error(err)
+	}
+	if err := (Metadata{Error: NotFoundError}).Err(); err != NotFoundError {
+		t.Error(err)
+	}
+
+	r := exampleResolver(t)
+	result := r.Resolve("did:example", ResolutionOptions{})
+	var parseErr *ParseError
+	if err := result.Metadata.Err(); !errors.Is(err, InvalidDIDError) || !errors.As(err, &parseErr) {
+		t.Error(err)
+	}
+	if b, _ := json.Marshal(result.Metadata); string(b) != fmt.Sprintf(`{"error":"invalidDid","problemDetails":{"type":"https://www.w3.org/ns/did#INVALID_DID","detail":%q}}`, parseErr) {
+		t.Error(string(b))
+	}
+	if err := r.Dereference("did:example:456", DereferencingOptions{}).Metadata.Err(); !errors.Is(err, NotFoundError) {
+		t.Error(err)
+	}
+
+	result = r.Resolve("did:other:123", ResolutionOptions{})
+	if result.Metadata.Error != MethodNotSupportedError {
+		t.Error(result.Metadata.Error)
+	}
+	// The deprecated name of the error code still matches.
+	if err := result.Metadata.Err(); !errors.Is(err, MethodNotSupportedError) || !errors.Is(err, UnsupportedDidMethodError) {
+		t.Error(err)
+	}
+	if errors.Is(UnsupportedDidMethodError, NotFoundError) {
+		t.Error("unexpected match")
+	}
+}
@@ -0,0 +1,129 @@
+package did
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Query is the query of a DID URL: an ordered list of parameters, possibly with duplicate keys.
+type Query []Parameter
+
+// ParseQuery parses the query component of a DID URL (without the leading "?"), percent-decoding keys and values.
+// Unlike form encoding, a "+" is not decoded to a space.
+func ParseQuery(query string) (Query, error) {
+	return parseQuery(query, true)
+}
+
+func parseQuery(query string, strict bool) (Query, error) {
+	var q Query
+	for _, kv := range strings.Split(query, "&") {
+		if kv == "" {
+			continue
+		}
+		k, v, hasValue := strings.Cut(kv, "=")
+		dk, err := url.PathUnescape(k)
+		if err == nil {
+			k = dk
+		} else if strict {
+			return nil, fmt.Errorf("invalid query: %w", err)
+		}
+		dv, err := url.PathUnescape(v)
+		if err == nil {
+			v = dv
+		} else if strict {
+			return nil, fmt.Errorf("invalid query: %w", err)
+		}
+		q = append(q, Parameter{
+			Key:     k,
+			Value:   v,
+			NoValue: !hasValue,
+		})
+	}
+	return q, nil
+}
+
+// Add appends the parameter to the query.
+func (q *Query) Add(key, value string) {
+	*q = append(*q, Parameter{Key: key, Value: value})
+}
+
+// Del removes all parameters with the given key.
+func (q *Query) Del(key string) {
+	var parameters Query
+	for _, p := range *q {
+		if p.Key != key {
+			parameters = append(parameters, p)
+		}
+	}
+	*q = parameters
+}
+
+// Encode encodes the query, percent-encoding keys and values where needed. Parameters without a value are encoded as
+// their key only.
+func (q Query) Encode() string {
+	var query []string
+	for _, p := range q {
+		if p.NoValue && p.Value == "" {
+			query = append(query, encodeQueryComponent(p.Key))
+			continue
+		}
+		query = append(query, fmt.Sprintf("%s=%s", encodeQueryComponent(p.Key), encodeQueryComponent(p.Value)))
+	}
+	return strings.Join(query, "&")
+}
+
+// Get returns the value of the first parameter with the given key.
+func (q Query) Get(key string) (string, bool) {
+	return getParameter(q, key)
+}
+
+// Has checks whether the query contains a parameter with the given key.
+func (q Query) Has(key string) bool {
+	_, ok := q.Get(key)
+	return ok
+}
+
+// Set replaces the value of the first parameter with the given key, removing all others with the same key. If there is
+// no such parameter, it is appended to the query.
+func (q *Query) Set(key, value string) {
+	var parameters Query
+	var set bool
+	for _, p := range *q {
+		if p.Key != key {
+			parameters = append(parameters, p)
+			continue
+		}
+		if !set {
+			parameters = append(parameters, Parameter{Key: key, Value: value})
+			set = true
+		}
+	}
+	if !set {
+		parameters = append(parameters, Parameter{Key: key, Value: value})
+	}
+	*q = parameters
+}
+
+// Values returns the values of all parameters with the given key, in order.
+func (q Query) Values(key string) []string {
+	var values []string
+	for _, p := range q {
+		if p.Key == key {
+			values = append(values, p.Value)
+		}
+	}
+	return values
+}
+
+// QueryParameters returns the parsed query of the DID URL. Invalid percent-encodings, only possible in manually
+// constructed DID URLs, are kept as is.
+func (d DIDURL) QueryParameters() Query {
+	q, _ := parseQuery(d.Query, false)
+	return q
+}
+
+// SetQuery replaces the query of the DID URL with the encoded query.
+func (d *DIDURL) SetQuery(q Query) {
+	d.Query = q.Encode()
+}
@@ -0,0 +1,88 @@
+package did_test
+
+import (
+	"fmt"
+	"github.com/0x51-dev/did/did"
+	"reflect"
+	"testing"
+)
+
+func ExampleQuery() {
+	u, _ := did.ParseDIDURL("did:example:123?service=agent&relativeRef=%2Fpath%20to&service=files")
+	q := u.QueryParameters()
+	fmt.Println(q.Values("service"))
+	fmt.Println(q.Get("relativeRef"))
+	q.Del("service")
+	q.Add("versionId", "1")
+	u.SetQuery(q)
+	fmt.Println(u)
+	// Output:
+	// [agent files]
+	// /path to true
+	// did:example:123?relativeRef=/path%20to&versionId=1
+}
+
+func TestParseQuery(t *testing.T) {
+	for _, test := range []struct {
+		query    string
+		expected did.Query
+		encoded  string
+	}{
+		{"", nil, ""},
+		{"a=1", did.Query{{Key: "a", Value: "1"}}, "a=1"},
+		{"b=2&a=1&b=3", did.Query{{Key: "b", Value: "2"}, {Key: "a", Value: "1"}, {Key: "b", Value: "3"}}, "b=2&a=1&b=3"},
+		{"a&b=", did.Query{{Key: "a", NoValue: true}, {Key: "b"}}, "a&b="},
+		{"a=1+1&b=%26%3D", did.Query{{Key: "a", Value: "1+1"}, {Key: "b", Value: "&="}}, "a=1%2B1&b=%26%3D"},
+		{"a=x=y", did.Query{{Key: "a", Value: "x=y"}}, "a=x%3Dy"},
+	} {
+		t.Run(test.query, func(t *testing.T) {
+			q, err := did.ParseQuery(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(q, test.expected) {
+				t.Error(q, test.expected)
+			}
+			if s := q.Encode(); s != test.encoded {
+				t.Error(s, test.encoded)
+			}
+		})
+	}
+	if _, err := did.ParseQuery("a=%zz"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestDIDURL_SetQuery_noValue(t *testing.T) {
+	u, err := did.ParseDIDURL("did:example:123?a&b=&c=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.QueryParameters()
+	if v, ok := q.Get("a"); !ok || v != "" {
+		t.Error(v, ok)
+	}
+	u.SetQuery(q)
+	if s := u.String(); s != "did:example:123?a&b=&c=1" {
+		t.Error(s)
+	}
+	q.Set("a", "2")
+	if s := q.Encode(); s != "a=2&b=&c=1" {
+		t.Error(s)
+	}
+}
+
+func TestQuery_Set(t *testing.T) {
+	q := did.Query{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}, {Key: "a", Value: "3"}}
+	q.Set("a", "4")
+	if s := q.Encode(); s != "a=4&b=2" {
+		t.Error(s)
+	}
+	q.Set("c", "5")
+	if s := q.Encode(); s != "a=4&b=2&c=5" {
+		t.Error(s)
+	}
+	if !q.Has("c") || q.Has("d") {
+		t.Error(q)
+	}
+}
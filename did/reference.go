@@ -0,0 +1,197 @@
+package did
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mergePaths merges a relative path with the path of the base DID URL.
+// DOCS: https://www.rfc-editor.org/rfc/rfc3986#section-5.2.3
+func mergePaths(base, ref string) string {
+	if base == "" {
+		return "/" + ref
+	}
+	return base[:strings.LastIndex(base, "/")+1] + ref
+}
+
+// splitReference splits a relative reference into its path, query and fragment components.
+func splitReference(ref string) (path string, query string, hasQuery bool, fragment string, hasFragment bool) {
+	path, fragment, hasFragment = strings.Cut(ref, "#")
+	path, query, hasQuery = strings.Cut(path, "?")
+	return
+}
+
+// Absolute returns a copy of the document in which all identifiers (verification methods, controllers, services and
+// references in verification relationships) are absolute DID URLs, resolved against the ID of the document.
+func (d *Document) Absolute() (*Document, error) {
+	doc := *d
+	doc.VerificationMethod = nil
+	for _, method := range d.VerificationMethod {
+		method, err := d.absoluteVerificationMethod(method)
+		if err != nil {
+			return nil, err
+		}
+		doc.VerificationMethod = append(doc.VerificationMethod, method)
+	}
+	for _, r := range Relationships {
+		relationship := doc.relationship(r)
+		methods := make([]IVerificationMethod, len(*relationship))
+		for i, method := range *relationship {
+			switch method := method.(type) {
+			case *VerificationMethod:
+				m, err := d.absoluteVerificationMethod(*method)
+				if err != nil {
+					return nil, err
+				}
+				methods[i] = &m
+			case *RelativeVerificationMethod:
+				ref, err := d.ID.ResolveReference(method.RelativeURL)
+				if err != nil {
+					return nil, err
+				}
+				methods[i] = &RelativeVerificationMethod{RelativeURL: ref.String()}
+			default:
+				methods[i] = method
+			}
+		}
+		*relationship = methods
+	}
+	doc.Service = nil
+	for _, service := range d.Service {
+		id, err := d.ID.ResolveReference(service.ID)
+		if err != nil {
+			return nil, err
+		}
+		service.ID = id.String()
+		doc.Service = append(doc.Service, service)
+	}
+	return &doc, nil
+}
+
+// FindService returns the service identified by the given (absolute or relative) reference.
+func (d *Document) FindService(ref string) *Service {
+	id, err := d.ID.ResolveReference(ref)
+	if err != nil {
+		return nil
+	}
+	for i, service := range d.Service {
+		if v, err := d.ID.ResolveReference(service.ID); err == nil && id.Equal(*v) {
+			return &d.Service[i]
+		}
+	}
+	return nil
+}
+
+// FindVerificationMethod returns the verification method identified by the given (absolute or relative) reference. Both
+// the verification methods of the document and the ones embedded in verification relationships are considered.
+func (d *Document) FindVerificationMethod(ref string) *VerificationMethod {
+	id, err := d.ID.ResolveReference(ref)
+	if err != nil {
+		return nil
+	}
+	for i, method := range d.VerificationMethod {
+		if v, err := d.ID.ResolveReference(method.ID); err == nil && id.Equal(*v) {
+			return &d.VerificationMethod[i]
+		}
+	}
+	for _, relationship := range Relationships {
+		for _, method := range *d.relationship(relationship) {
+			method, ok := method.(*VerificationMethod)
+			if !ok {
+				continue
+			}
+			if v, err := d.ID.ResolveReference(method.ID); err == nil && id.Equal(*v) {
+				return method
+			}
+		}
+	}
+	return nil
+}
+
+// ResolveReference resolves the given (relative) reference against the document ID.
+func (d *Document) ResolveReference(ref string) (*DIDURL, error) {
+	return d.ID.ResolveReference(ref)
+}
+
+func (d *Document) absoluteVerificationMethod(method VerificationMethod) (VerificationMethod, error) {
+	id, err := d.ID.ResolveReference(method.ID)
+	if err != nil {
+		return method, err
+	}
+	method.ID = id.String()
+	if method.Controller != "" {
+		controller, err := d.ID.ResolveReference(method.Controller)
+		if err != nil {
+			return method, err
+		}
+		method.Controller = controller.String()
+	}
+	return method, nil
+}
+
+// ResolveReference resolves the given reference against the DID, following RFC 3986.
+func (d DID) ResolveReference(ref string) (*DIDURL, error) {
+	return d.URL().ResolveReference(ref)
+}
+
+// ResolveReference resolves the given reference against the DID URL, following RFC 3986. The DID itself (method and
+// method-specific identifier) acts as the authority of the DID URL: a reference can not replace it, unless it is an
+// absolute DID URL. A reference starting with ";" replaces the DID parameters, the path, query and fragment.
+// DOCS: https://www.rfc-editor.org/rfc/rfc3986#section-5.2
+func (d DIDURL) ResolveReference(ref string) (*DIDURL, error) {
+	if strings.HasPrefix(ref, "did:") {
+		u, err := ParseDIDURL(ref)
+		if err != nil {
+			return nil, err
+		}
+		u.Path = removeDotSegments(u.Path)
+		return u, nil
+	}
+	if strings.HasPrefix(ref, "//") {
+		return nil, fmt.Errorf("invalid reference: %s: DID URLs do not have an authority", ref)
+	}
+	if i := strings.IndexAny(ref, ":/?#"); i != -1 && ref[i] == ':' {
+		return nil, fmt.Errorf("invalid reference: %s: not a DID URL", ref)
+	}
+
+	t := DIDURL{
+		Method:    d.Method,
+		MethodIDs: d.MethodIDs,
+	}
+	if strings.HasPrefix(ref, ";") {
+		// Parameters (and everything after it) are replaced, similar to an absolute path.
+		u, err := ParseDIDURL(t.DID().String() + ref)
+		if err != nil {
+			return nil, err
+		}
+		u.Path = removeDotSegments(u.Path)
+		return u, nil
+	}
+
+	path, query, hasQuery, fragment, hasFragment := splitReference(ref)
+	t.Parameters = d.Parameters
+	switch {
+	case path == "":
+		t.Path = d.Path
+		t.Query = d.Query
+		if hasQuery {
+			t.Query = query
+		}
+	case strings.HasPrefix(path, "/"):
+		t.Path = removeDotSegments(path)
+		t.Query = query
+	default:
+		t.Path = removeDotSegments(mergePaths(d.Path, path))
+		t.Query = query
+	}
+	if hasFragment {
+		t.Fragment = fragment
+	}
+
+	// Validate the result.
+	u, err := ParseDIDURL(t.String())
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
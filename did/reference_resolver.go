@@ -0,0 +1,141 @@
+package did
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxDepth is the default maximum length of the controller chain followed by a ReferenceResolver.
+const DefaultMaxDepth = 4
+
+var (
+	// ErrMaxDepth is returned if a referenced DID is not found within the maximum length of the controller chain.
+	ErrMaxDepth = errors.New("maximum controller depth exceeded")
+	// ErrNotController is returned if a verification method is referenced from a document it has no authority over.
+	ErrNotController = errors.New("not a controller of the document")
+)
+
+// ReferenceResolver resolves the verification methods referenced by verification relationships, including the ones
+// defined in the documents of other DIDs. A method of another DID is only accepted if that DID controls the document,
+// either directly or through a chain of controllers, and if the method is controlled by that DID.
+// DOCS: https://www.w3.org/TR/did-core/#referring-to-verification-methods
+type ReferenceResolver struct {
+	Resolver Resolvable
+	Options  ResolutionOptions
+	// MaxDepth is the maximum length of the controller chain, defaults to DefaultMaxDepth.
+	MaxDepth int
+}
+
+// MethodsFor returns the verification methods of the given relationship, see Document.MethodsFor. References to other
+// DID documents are resolved, references that can not be resolved are skipped and reported.
+func (r ReferenceResolver) MethodsFor(doc *Document, relationship Relationship) ([]VerificationMethod, error) {
+	methods := doc.relationship(relationship)
+	if methods == nil {
+		return nil, fmt.Errorf("unknown verification relationship: %s", relationship)
+	}
+	var resolved []VerificationMethod
+	var errs []error
+	for _, method := range *methods {
+		ref, ok := method.(*RelativeVerificationMethod)
+		if !ok {
+			m := method.Get(doc.VerificationMethod)
+			if m == nil {
+				errs = append(errs, &DanglingReferenceError{Relationship: relationship, Reference: fmt.Sprint(method)})
+				continue
+			}
+			resolved = append(resolved, *m)
+			continue
+		}
+		m, err := r.Resolve(doc, ref.RelativeURL)
+		if err != nil {
+			var danglingErr *DanglingReferenceError
+			if errors.As(err, &danglingErr) {
+				danglingErr.Relationship = relationship
+			}
+			errs = append(errs, err)
+			continue
+		}
+		resolved = append(resolved, *m)
+	}
+	return resolved, errors.Join(errs...)
+}
+
+// Resolve returns the verification method identified by the given (absolute or relative) reference, resolved against
+// the ID of the document.
+func (r ReferenceResolver) Resolve(doc *Document, ref string) (*VerificationMethod, error) {
+	u, err := doc.ID.ResolveReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	target := u.DID()
+	if target.Equal(doc.ID) {
+		if m := doc.FindVerificationMethod(ref); m != nil {
+			return m, nil
+		}
+		return nil, &DanglingReferenceError{Reference: ref}
+	}
+
+	maxDepth := r.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+	// Breadth-first search through the controllers, every DID is only resolved once to break cycles. Controllers that
+	// can not be resolved are skipped, the target can still be reached through the others.
+	visited := map[string]bool{doc.ID.Normalize().String(): true}
+	var errs []error
+	level := doc.Controller
+	for depth := 0; depth < maxDepth && len(level) != 0; depth++ {
+		var next []DID
+		for _, controller := range level {
+			key := controller.Normalize().String()
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+
+			controllerDoc, err := r.resolve(controller)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if !controller.Equal(target) {
+				next = append(next, controllerDoc.Controller...)
+				continue
+			}
+			m := controllerDoc.FindVerificationMethod(u.String())
+			if m == nil {
+				return nil, &DanglingReferenceError{Reference: ref}
+			}
+			c, err := controllerDoc.ID.ResolveReference(m.Controller)
+			if err != nil || !c.DID().Equal(target) {
+				return nil, fmt.Errorf("%s: method controlled by %q: %w", ref, m.Controller, ErrNotController)
+			}
+			return m, nil
+		}
+		level = next
+	}
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("%s: %w", ref, errors.Join(errs...))
+	}
+	for _, controller := range level {
+		if !visited[controller.Normalize().String()] {
+			return nil, fmt.Errorf("%s: %w", ref, ErrMaxDepth)
+		}
+	}
+	return nil, fmt.Errorf("%s: %s: %w", ref, target, ErrNotController)
+}
+
+// resolve resolves the DID document of the given DID.
+func (r ReferenceResolver) resolve(did DID) (*Document, error) {
+	result := r.Resolver.Resolve(did.String(), r.Options)
+	if err := result.Metadata.Err(); err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", did, err)
+	}
+	if result.Document == nil {
+		return nil, fmt.Errorf("resolve %s: %w", did, NotFoundError)
+	}
+	if !result.Document.ID.Equal(did) {
+		return nil, fmt.Errorf("resolve %s: document has id %s", did, result.Document.ID)
+	}
+	return result.Document, nil
+}
@@ -0,0 +1,123 @@
+package did
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func documentResolver(t *testing.T, documents ...string) DefaultResolver {
+	docs := make(map[string]*Document)
+	for _, raw := range documents {
+		doc, err := ParseDocument([]byte(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs[doc.ID.String()] = doc
+	}
+	return DefaultResolver{
+		Registry: Registry{
+			"example": func(_ string, did DID, _ Resolvable, _ ResolutionOptions) ResolutionResult {
+				doc, ok := docs[did.String()]
+				if !ok {
+					return ResolutionResult{Metadata: Metadata{Error: NotFoundError}}
+				}
+				return ResolutionResult{Document: doc}
+			},
+		},
+	}
+}
+
+func controlledDocument(id, controller string) string {
+	return fmt.Sprintf(`{
+		"id": %q,
+		"controller": %q,
+		"verificationMethod": [{"id": "#key-1", "type": "Multikey", "controller": %q, "publicKeyMultibase": "z6Mk"}]
+	}`, id, controller, id)
+}
+
+func TestReferenceResolver_Resolve(t *testing.T) {
+	r := ReferenceResolver{
+		Resolver: documentResolver(t,
+			controlledDocument("did:example:a", "did:example:b"),
+			controlledDocument("did:example:b", "did:example:c"),
+			controlledDocument("did:example:c", "did:example:a"),
+			controlledDocument("did:example:d", "did:example:d"),
+			`{"id": "did:example:e", "verificationMethod": [{"id": "#key-1", "type": "Multikey", "controller": "did:example:a", "publicKeyMultibase": "z6Mk"}]}`,
+		),
+	}
+	doc, err := ParseDocument([]byte(`{
+		"id": "did:example:123",
+		"controller": ["did:example:a", "did:example:e"],
+		"verificationMethod": [{"id": "#key-1", "type": "Multikey", "controller": "did:example:123", "publicKeyMultibase": "z6Mk"}],
+		"authentication": ["#key-1", "did:example:a#key-1", "did:example:c#key-1"],
+		"assertionMethod": ["did:example:d#key-1", "did:example:e#key-1", "did:example:a#key-2", "#key-2"]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ref := range []string{"#key-1", "did:example:a#key-1", "did:example:c#key-1"} {
+		if m, err := r.Resolve(doc, ref); err != nil || m.ID != "#key-1" {
+			t.Error(ref, m, err)
+		}
+	}
+	methods, err := r.MethodsFor(doc, Authentication)
+	if err != nil || len(methods) != 3 {
+		t.Error(methods, err)
+	}
+
+	if _, err := r.Resolve(doc, "did:example:d#key-1"); !errors.Is(err, ErrNotController) {
+		t.Error(err)
+	}
+	if _, err := r.Resolve(doc, "did:example:e#key-1"); !errors.Is(err, ErrNotController) {
+		t.Error(err)
+	}
+	if _, err := (ReferenceResolver{Resolver: r.Resolver, MaxDepth: 2}).Resolve(doc, "did:example:c#key-1"); !errors.Is(err, ErrMaxDepth) {
+		t.Error(err)
+	}
+	methods, err = r.MethodsFor(doc, AssertionMethod)
+	if len(methods) != 0 {
+		t.Error(methods)
+	}
+	var danglingErr *DanglingReferenceError
+	if !errors.As(err, &danglingErr) || danglingErr.Relationship != AssertionMethod || danglingErr.Reference != "did:example:a#key-2" {
+		t.Error(err)
+	}
+}
+
+func TestReferenceResolver_Resolve_unresolvableController(t *testing.T) {
+	r := ReferenceResolver{
+		Resolver: documentResolver(t,
+			controlledDocument("did:example:a", "did:example:b"),
+			controlledDocument("did:example:b", "did:example:b"),
+		),
+	}
+	doc, err := ParseDocument([]byte(`{
+		"id": "did:example:123",
+		"controller": ["did:example:missing", "did:example:a"]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The controller that can not be resolved does not block the search through the other controllers.
+	for _, ref := range []string{"did:example:a#key-1", "did:example:b#key-1"} {
+		if m, err := r.Resolve(doc, ref); err != nil || m.ID != "#key-1" {
+			t.Error(ref, m, err)
+		}
+	}
+	// Results without a document (and without an error) are not found.
+	empty := ReferenceResolver{Resolver: DefaultResolver{Registry: Registry{
+		"example": func(string, DID, Resolvable, ResolutionOptions) ResolutionResult { return ResolutionResult{} },
+	}}}
+	if _, err := empty.Resolve(doc, "did:example:a#key-1"); !errors.Is(err, NotFoundError) {
+		t.Error(err)
+	}
+	// The target is not reached, the resolution error is reported.
+	if _, err := r.Resolve(doc, "did:example:missing#key-1"); !errors.Is(err, NotFoundError) {
+		t.Error(err)
+	}
+	if _, err := r.Resolve(doc, "did:example:c#key-1"); !errors.Is(err, NotFoundError) {
+		t.Error(err)
+	}
+}
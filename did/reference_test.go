@@ -0,0 +1,101 @@
+package did
+
+import (
+	"testing"
+)
+
+func TestDIDURL_ResolveReference(t *testing.T) {
+	base, _ := ParseDIDURL("did:example:123;p=1/a/b/c?q#f")
+	for _, test := range []struct {
+		ref      string
+		expected string
+	}{
+		{"", "did:example:123;p=1/a/b/c?q"},
+		{"#key-1", "did:example:123;p=1/a/b/c?q#key-1"},
+		{"?service=agent", "did:example:123;p=1/a/b/c?service=agent"},
+		{"g", "did:example:123;p=1/a/b/g"},
+		{"./g", "did:example:123;p=1/a/b/g"},
+		{"g/", "did:example:123;p=1/a/b/g/"},
+		{"/g", "did:example:123;p=1/g"},
+		{"g?y#s", "did:example:123;p=1/a/b/g?y#s"},
+		{"..", "did:example:123;p=1/a/"},
+		{"../g", "did:example:123;p=1/a/g"},
+		{"../../../g", "did:example:123;p=1/g"},
+		{";service=agent", "did:example:123;service=agent"},
+		{";service=agent#key-1", "did:example:123;service=agent#key-1"},
+		{"did:example:456/a/../b", "did:example:456/b"},
+	} {
+		t.Run(test.ref, func(t *testing.T) {
+			u, err := base.ResolveReference(test.ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := u.String(); s != test.expected {
+				t.Error(s, test.expected)
+			}
+		})
+	}
+
+	for _, ref := range []string{
+		"//example.com",
+		"https://example.com",
+		"did:example",
+		";",
+		"#key 1",
+	} {
+		if u, err := base.ResolveReference(ref); err == nil {
+			t.Errorf("expected error for %q, got %s", ref, u.String())
+		}
+	}
+}
+
+func TestDocument_Absolute(t *testing.T) {
+	doc, err := ParseDocument(example21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	abs, err := doc.Absolute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref := abs.Authentication[0].(*RelativeVerificationMethod).RelativeURL; ref != "did:example:123#keys-1" {
+		t.Error(ref)
+	}
+	if id := abs.Authentication[1].(*VerificationMethod).ID; id != "did:example:123#keys-2" {
+		t.Error(id)
+	}
+	if id := abs.Service[0].ID; id != "did:example:123#files" {
+		t.Error(id)
+	}
+	if m := abs.Authentication[0].Get(abs.VerificationMethod); m == nil || m.ID != "did:example:123#keys-1" {
+		t.Error(m)
+	}
+	// The original document is left untouched.
+	if id := doc.Service[0].ID; id != "#files" {
+		t.Error(id)
+	}
+}
+
+func TestDocument_FindVerificationMethod(t *testing.T) {
+	doc, err := ParseDocument(example9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range []string{
+		"#key-1",
+		"did:example:123456789abcdefghi#key-1",
+		"did:example:123456789abcdefghi#%6Bey-1",
+	} {
+		if m := doc.FindVerificationMethod(ref); m == nil {
+			t.Errorf("expected method for %q", ref)
+		}
+	}
+	for _, ref := range []string{
+		"#key-2",
+		"did:example:other#key-1",
+	} {
+		if m := doc.FindVerificationMethod(ref); m != nil {
+			t.Errorf("unexpected method for %q", ref)
+		}
+	}
+}
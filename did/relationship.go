@@ -0,0 +1,126 @@
+package did
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Relationship is a verification relationship between the DID subject and a verification method.
+// DOCS: https://www.w3.org/TR/did-core/#verification-relationships
+type Relationship string
+
+const (
+	// Authentication - how the DID subject is expected to be authenticated.
+	Authentication Relationship = "authentication"
+	// AssertionMethod - how the DID subject is expected to express claims, e.g. for issuing verifiable credentials.
+	AssertionMethod Relationship = "assertionMethod"
+	// KeyAgreement - how an entity can generate encryption material to transmit confidential information.
+	KeyAgreement Relationship = "keyAgreement"
+	// CapabilityInvocation - how the DID subject is expected to invoke a cryptographic capability.
+	CapabilityInvocation Relationship = "capabilityInvocation"
+	// CapabilityDelegation - how the DID subject is expected to delegate a cryptographic capability to another party.
+	CapabilityDelegation Relationship = "capabilityDelegation"
+)
+
+// Relationships are all verification relationships defined in the DID specification, in the order of the document.
+var Relationships = []Relationship{
+	Authentication,
+	AssertionMethod,
+	KeyAgreement,
+	CapabilityInvocation,
+	CapabilityDelegation,
+}
+
+// DanglingReferenceError is returned if a verification relationship references a verification method that is not
+// defined in the document.
+type DanglingReferenceError struct {
+	Relationship Relationship
+	Reference    string
+}
+
+func (e *DanglingReferenceError) Error() string {
+	if e.Relationship == "" {
+		return fmt.Sprintf("%q references a non-existent verification method", e.Reference)
+	}
+	return fmt.Sprintf("%s: %q references a non-existent verification method", e.Relationship, e.Reference)
+}
+
+// MethodsFor returns the verification methods of the given relationship, both the embedded and the referenced ones.
+// References that can not be resolved within the document are skipped and reported as DanglingReferenceErrors.
+func (d *Document) MethodsFor(relationship Relationship) ([]VerificationMethod, error) {
+	methods := d.relationship(relationship)
+	if methods == nil {
+		return nil, fmt.Errorf("unknown verification relationship: %s", relationship)
+	}
+	var resolved []VerificationMethod
+	var errs []error
+	for _, method := range *methods {
+		var m *VerificationMethod
+		switch method := method.(type) {
+		case *VerificationMethod:
+			m = method
+		case *RelativeVerificationMethod:
+			if m = d.FindVerificationMethod(method.RelativeURL); m == nil {
+				errs = append(errs, &DanglingReferenceError{Relationship: relationship, Reference: method.RelativeURL})
+				continue
+			}
+		default:
+			if m = method.Get(d.VerificationMethod); m == nil {
+				errs = append(errs, &DanglingReferenceError{Relationship: relationship, Reference: fmt.Sprint(method)})
+				continue
+			}
+		}
+		resolved = append(resolved, *m)
+	}
+	return resolved, errors.Join(errs...)
+}
+
+// RelationshipsOf returns the verification relationships for which the verification method with the given (absolute
+// or relative) ID is authorized, either embedded or by reference.
+func (d *Document) RelationshipsOf(id string) []Relationship {
+	u, err := d.ID.ResolveReference(id)
+	if err != nil {
+		return nil
+	}
+	var relationships []Relationship
+	for _, relationship := range Relationships {
+		for _, method := range *d.relationship(relationship) {
+			var ref string
+			switch method := method.(type) {
+			case *VerificationMethod:
+				ref = method.ID
+			case *RelativeVerificationMethod:
+				ref = method.RelativeURL
+			default:
+				m := method.Get(d.VerificationMethod)
+				if m == nil {
+					continue
+				}
+				ref = m.ID
+			}
+			if v, err := d.ID.ResolveReference(ref); err == nil && u.Equal(*v) {
+				relationships = append(relationships, relationship)
+				break
+			}
+		}
+	}
+	return relationships
+}
+
+// relationship returns a pointer to the verification methods of the given relationship, or nil if it is unknown.
+func (d *Document) relationship(relationship Relationship) *[]IVerificationMethod {
+	switch relationship {
+	case Authentication:
+		return &d.Authentication
+	case AssertionMethod:
+		return &d.AssertionMethod
+	case KeyAgreement:
+		return &d.KeyAgreement
+	case CapabilityInvocation:
+		return &d.CapabilityInvocation
+	case CapabilityDelegation:
+		return &d.CapabilityDelegation
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,58 @@
+package did
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDocument_MethodsFor(t *testing.T) {
+	doc, err := ParseDocument(example21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	methods, err := doc.MethodsFor(Authentication)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(methods) != 2 || methods[0].ID != "did:example:123#keys-1" || methods[1].ID != "#keys-2" {
+		t.Error(methods)
+	}
+	if methods, err := doc.MethodsFor(AssertionMethod); err != nil || len(methods) != 0 {
+		t.Error(methods, err)
+	}
+	if _, err := doc.MethodsFor("unknown"); err == nil {
+		t.Error("expected an error")
+	}
+
+	doc.Authentication = append(doc.Authentication, &RelativeVerificationMethod{RelativeURL: "#keys-3"})
+	methods, err = doc.MethodsFor(Authentication)
+	var danglingErr *DanglingReferenceError
+	if !errors.As(err, &danglingErr) || danglingErr.Reference != "#keys-3" || danglingErr.Relationship != Authentication {
+		t.Fatal(err)
+	}
+	if len(methods) != 2 {
+		t.Error(methods)
+	}
+}
+
+func TestDocument_RelationshipsOf(t *testing.T) {
+	doc, err := ParseDocument(example21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.CapabilityInvocation = []IVerificationMethod{&RelativeVerificationMethod{RelativeURL: "did:example:123#keys-1"}}
+	for _, test := range []struct {
+		id            string
+		relationships []Relationship
+	}{
+		{id: "#keys-1", relationships: []Relationship{Authentication, CapabilityInvocation}},
+		{id: "did:example:123#keys-2", relationships: []Relationship{Authentication}},
+		{id: "#keys-3"},
+		{id: "did:example:456#keys-1"},
+	} {
+		if relationships := doc.RelationshipsOf(test.id); !reflect.DeepEqual(relationships, test.relationships) {
+			t.Error(test.id, relationships)
+		}
+	}
+}
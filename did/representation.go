@@ -0,0 +1,43 @@
+package did
+
+import (
+	"fmt"
+)
+
+// MarshalRepresentation returns the representation of the document of the given media type (MediaTypeJSON,
+// MediaTypeJSONLD or MediaTypeCBOR), other media types result in a RepresentationNotSupportedError.
+// DOCS: https://www.w3.org/TR/did-core/#production-and-consumption
+func (d *Document) MarshalRepresentation(mediaType string) ([]byte, error) {
+	switch mediaType {
+	case MediaTypeJSON:
+		return d.MarshalJSON()
+	case MediaTypeJSONLD:
+		return d.MarshalJSONLD()
+	case MediaTypeCBOR:
+		return d.MarshalCBOR()
+	default:
+		return nil, fmt.Errorf("%w: %q", RepresentationNotSupportedError, mediaType)
+	}
+}
+
+// ParseRepresentation parses the representation of a DID document of the given media type, see
+// Document.MarshalRepresentation.
+func ParseRepresentation(raw []byte, mediaType string, options ...ParseOptions) (*Document, error) {
+	switch mediaType {
+	case MediaTypeJSON:
+		return ParseDocument(raw, options...)
+	case MediaTypeJSONLD:
+		doc, err := ParseDocumentJSONLD(raw)
+		if err != nil {
+			return nil, err
+		}
+		if err := doc.validateParsed(options); err != nil {
+			return nil, err
+		}
+		return doc, nil
+	case MediaTypeCBOR:
+		return ParseDocumentCBOR(raw, options...)
+	default:
+		return nil, fmt.Errorf("%w: %q", RepresentationNotSupportedError, mediaType)
+	}
+}
@@ -0,0 +1,36 @@
+package did
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDocument_MarshalRepresentation(t *testing.T) {
+	doc, err := ParseDocument(example21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mediaType := range []string{MediaTypeJSON, MediaTypeJSONLD, MediaTypeCBOR} {
+		raw, err := doc.MarshalRepresentation(mediaType)
+		if err != nil {
+			t.Fatal(mediaType, err)
+		}
+		parsed, err := ParseRepresentation(raw, mediaType, ParseOptions{Strict: true})
+		if err != nil {
+			t.Fatal(mediaType, err)
+		}
+		if changes, err := Diff(doc, parsed); err != nil || len(changes) != 0 {
+			t.Error(mediaType, changes, err)
+		}
+	}
+	if _, err := doc.MarshalRepresentation("application/did+yaml"); !errors.Is(err, RepresentationNotSupportedError) {
+		t.Error(err)
+	}
+	if _, err := ParseRepresentation(example21, "application/did+yaml"); !errors.Is(err, RepresentationNotSupportedError) {
+		t.Error(err)
+	}
+	// The JSON-LD representation requires a @context.
+	if _, err := ParseRepresentation([]byte(`{"id": "did:example:123"}`), MediaTypeJSONLD); err == nil {
+		t.Error("expected an error")
+	}
+}
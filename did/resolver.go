@@ -1,5 +1,9 @@
 package did
 
+import (
+	"fmt"
+)
+
 type ConditionWeightedThreshold struct {
 	Condition VerificationMethod
 	Weight    int
@@ -7,50 +11,128 @@ type ConditionWeightedThreshold struct {
 
 type DefaultResolver struct {
 	Registry Registry
+	// Additional method-specific identifier validators, next to the ones registered with RegisterValidator.
+	Validators Validators
 }
 
 func (r DefaultResolver) Resolve(didURL string, options ResolutionOptions) ResolutionResult {
-	u, err := ParseDID(didURL)
+	u, err := ParseBareDID(didURL)
 	if err != nil {
-		return ResolutionResult{
-			Metadata: Metadata{
-				Error: InvalidDIDError,
-			},
-		}
+		return NewErrorResult(InvalidDIDError, err)
+	}
+	if err := r.Validators.Validate(*u); err != nil {
+		return NewErrorResult(InvalidDIDError, err)
 	}
 	resolver, ok := r.Registry[u.Method]
 	if !ok {
-		return ResolutionResult{
-			Metadata: Metadata{
-				Error: UnsupportedDidMethodError,
-			},
+		return NewErrorResult(MethodNotSupportedError, fmt.Errorf("unsupported DID method: %s", u.Method))
+	}
+	methodOptions := options
+	if options.Accept == MediaTypeCBOR {
+		// Methods resolve the JSON representation, the CBOR representation is produced from the (same) data model.
+		methodOptions.Accept = MediaTypeJSON
+	}
+	result := resolver(didURL, *u, r, methodOptions)
+	if result.Document == nil {
+		return result
+	}
+	if options.Strict {
+		if err := result.Document.Validate().Err(); err != nil {
+			return NewErrorResult(InvalidDIDDocumentError, err)
+		}
+	}
+	switch options.Accept {
+	case MediaTypeJSON, MediaTypeJSONLD, MediaTypeCBOR:
+		if result.DocumentStream == nil {
+			stream, err := result.Document.MarshalRepresentation(options.Accept)
+			if err != nil {
+				return NewErrorResult(InternalError, err)
+			}
+			result.Metadata.ContentType = options.Accept
+			result.DocumentStream = stream
 		}
 	}
-	return resolver(didURL, *u, r, options)
+	return result
 }
 
+// Error is a DID resolution (or dereferencing) error code. It implements the error interface, so it can be matched with
+// errors.Is, also when wrapped in ProblemDetails.
+// DOCS: https://w3c-ccg.github.io/did-resolution/#errors
 type Error string
 
 const (
+	// FeatureNotSupportedError - The DID resolver does not support a feature required by the resolution request, e.g.
+	// a DID parameter.
+	FeatureNotSupportedError Error = "featureNotSupported"
+	// InternalError - An unexpected error occurred during the resolution process, e.g. the verifiable data registry
+	// could not be reached.
+	InternalError Error = "internalError"
 	// InvalidDIDError - the supplied DID to the DID resolution function does not conform to valid syntax.
 	InvalidDIDError Error = "invalidDid"
+	// InvalidDIDDocumentError - The DID document resulting from the resolution request is invalid.
+	InvalidDIDDocumentError Error = "invalidDidDocument"
+	// InvalidDIDURLError - The DID URL supplied to the DID URL dereferencing function does not conform to valid syntax.
+	InvalidDIDURLError Error = "invalidDidUrl"
+	// InvalidOptionsError - The resolution or dereferencing options are invalid.
+	InvalidOptionsError Error = "invalidOptions"
+	// InvalidPublicKeyError - The public key, e.g. encoded in the DID itself, is invalid.
+	InvalidPublicKeyError Error = "invalidPublicKey"
+	// InvalidPublicKeyLengthError - The public key has an invalid length.
+	InvalidPublicKeyLengthError Error = "invalidPublicKeyLength"
+	// InvalidPublicKeyTypeError - The public key has an invalid type.
+	InvalidPublicKeyTypeError Error = "invalidPublicKeyType"
+	// MethodNotSupportedError - The DID method of the DID is not supported by the DID resolver.
+	MethodNotSupportedError Error = "methodNotSupported"
+	// NotAllowedError - The resolution request is not allowed, e.g. because of access control.
+	NotAllowedError Error = "notAllowed"
 	// NotFoundError - The DID resolver was unable to find the DID document resulting from this resolution request.
 	NotFoundError Error = "notFound"
 	// RepresentationNotSupportedError - This error code is returned if the representation requested via the accept
 	// input metadata property is not supported by the DID method and/or DID resolver implementation.
 	RepresentationNotSupportedError Error = "representationNotSupported"
 	// UnsupportedDidMethodError - This error code is returned if the DID method specified in the DID is not supported.
+	//
+	// Deprecated: the name of MethodNotSupportedError in earlier drafts of the DID Resolution specification, which is
+	// returned instead. Both codes match each other with errors.Is.
 	UnsupportedDidMethodError Error = "unsupportedDidMethod"
+	// UnsupportedPublicKeyTypeError - The type of the public key is not supported.
+	UnsupportedPublicKeyTypeError Error = "unsupportedPublicKeyType"
 )
 
+func (e Error) Error() string {
+	return string(e)
+}
+
+// Is matches the renamed error codes, UnsupportedDidMethodError matches MethodNotSupportedError and vice versa.
+func (e Error) Is(target error) bool {
+	t, ok := target.(Error)
+	return ok && e.canonical() == t.canonical()
+}
+
+// canonical returns the current name of the error code.
+func (e Error) canonical() Error {
+	if e == UnsupportedDidMethodError {
+		return MethodNotSupportedError
+	}
+	return e
+}
+
 // Metadata are metadata about the DID resolution process.
 // DOCS: https://www.w3.org/TR/did-core/#did-resolution-metadata
 type Metadata struct {
 	// The Media Type of the returned didDocument.
-	ContentType string
+	ContentType string `json:"contentType,omitempty"`
 	// The error code from the resolution process.
 	// This property is REQUIRED when there is an error in the resolution process
-	Error Error
+	Error Error `json:"error,omitempty"`
+	// Details about the error, including its underlying cause.
+	ProblemDetails *ProblemDetails `json:"problemDetails,omitempty"`
+}
+
+// Err returns the error of the resolution process, or nil if there is none. The error matches its error code with
+// errors.Is, and its cause (if any) with errors.Is and errors.As.
+func (m Metadata) Err() error {
+	return resolutionErr(m.Error, m.ProblemDetails)
 }
 
 type Registry map[string]Resolver
@@ -60,11 +142,23 @@ type Registry map[string]Resolver
 type ResolutionOptions struct {
 	// The Media Type of the caller's preferred representation of the DID document.
 	Accept string
+	// Strict rejects DID documents that violate any of the conformance requirements of the DID specification.
+	Strict bool
 }
 
+// ResolutionResult is the result of resolving a DID, it is serialized as a DID resolution result.
+// DOCS: https://w3c-ccg.github.io/did-resolution/#did-resolution-result
 type ResolutionResult struct {
-	Metadata Metadata  `json:"metadata"`
+	// Metadata about the resolution process.
+	Metadata Metadata `json:"didResolutionMetadata"`
+	// The resolved DID document, nil if the resolution was not successful.
 	Document *Document `json:"didDocument"`
+	// Metadata about the resolved DID document.
+	DocumentMetadata DocumentMetadata `json:"didDocumentMetadata"`
+	// DocumentStream is the resolved DID document in the representation of the content type, if it was requested with
+	// ResolutionOptions.Accept. It is not part of the serialized result, which contains the document itself.
+	// DOCS: https://w3c-ccg.github.io/did-resolution/#did-resolution-result
+	DocumentStream []byte `json:"-"`
 }
 
 type Resolvable interface {
@@ -0,0 +1,54 @@
+package did
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDefaultResolver_Resolve_validators(t *testing.T) {
+	r := exampleResolver(t)
+	r.Validators = Validators{
+		"example": func(did DID) error {
+			if strings.ContainsAny(did.MethodIDs[0], "abcdefghijklmnopqrstuvwxyz") {
+				return fmt.Errorf("expected a number")
+			}
+			return nil
+		},
+	}
+	if result := r.Resolve("did:example:123", ResolutionOptions{}); result.Metadata.Error != "" {
+		t.Error(result.Metadata.Error)
+	}
+	if result := r.Resolve("did:example:abc", ResolutionOptions{}); result.Metadata.Error != InvalidDIDError {
+		t.Error(result.Metadata.Error)
+	}
+}
+
+func TestDefaultResolver_Resolve_accept(t *testing.T) {
+	r := exampleResolver(t)
+	result := r.Resolve("did:example:123", ResolutionOptions{Accept: MediaTypeCBOR})
+	if err := result.Metadata.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if result.Metadata.ContentType != MediaTypeCBOR {
+		t.Error(result.Metadata.ContentType)
+	}
+	doc, err := ParseDocumentCBOR(result.DocumentStream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes, err := Diff(result.Document, doc); err != nil || len(changes) != 0 {
+		t.Error(changes, err)
+	}
+
+	if result := r.Resolve("did:example:123", ResolutionOptions{}); result.DocumentStream != nil || result.Metadata.ContentType != MediaTypeJSON {
+		t.Error(result.Metadata)
+	}
+	if result := r.Resolve("did:example:123", ResolutionOptions{Accept: MediaTypeJSON}); !equalJSON(json.RawMessage(result.DocumentStream), result.Document) {
+		t.Error(string(result.DocumentStream))
+	}
+	if result := r.Resolve("did:example:456", ResolutionOptions{Accept: MediaTypeCBOR}); result.Metadata.Error != NotFoundError || result.DocumentStream != nil {
+		t.Error(result.Metadata)
+	}
+}
@@ -0,0 +1,84 @@
+package did
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"math/big"
+)
+
+// secp256k1P is the prime of the field of the secp256k1 curve: y² = x³ + 7.
+// DOCS: https://www.secg.org/sec2-v2.pdf
+var secp256k1P, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+
+// Secp256k1PublicKey is a public key on the secp256k1 curve in its compressed form (33 bytes). The curve is not
+// supported by the crypto packages of the standard library, the key can be passed to third-party libraries.
+type Secp256k1PublicKey []byte
+
+// NewSecp256k1PublicKey parses a compressed (33 bytes) or uncompressed (65 bytes) secp256k1 public key.
+func NewSecp256k1PublicKey(b []byte) (Secp256k1PublicKey, error) {
+	switch {
+	case len(b) == 33 && (b[0] == 0x02 || b[0] == 0x03):
+		x := new(big.Int).SetBytes(b[1:])
+		if secp256k1Y(x, b[0] == 0x03) == nil {
+			return nil, fmt.Errorf("invalid secp256k1 public key: point is not on the curve")
+		}
+		return append(Secp256k1PublicKey(nil), b...), nil
+	case len(b) == 65 && b[0] == 0x04:
+		return newSecp256k1PublicKey(new(big.Int).SetBytes(b[1:33]), new(big.Int).SetBytes(b[33:]))
+	default:
+		return nil, fmt.Errorf("invalid secp256k1 public key: %d bytes", len(b))
+	}
+}
+
+// newSecp256k1PublicKey returns the public key of the given point.
+func newSecp256k1PublicKey(x, y *big.Int) (Secp256k1PublicKey, error) {
+	if v := secp256k1Y(x, y.Bit(0) == 1); v == nil || v.Cmp(y) != 0 {
+		return nil, fmt.Errorf("invalid secp256k1 public key: point is not on the curve")
+	}
+	k := make(Secp256k1PublicKey, 33)
+	k[0] = 0x02 | byte(y.Bit(0))
+	x.FillBytes(k[1:])
+	return k, nil
+}
+
+// secp256k1Y returns the y coordinate of the point with the given x coordinate and parity, or nil if there is none.
+func secp256k1Y(x *big.Int, odd bool) *big.Int {
+	if secp256k1P.Cmp(x) <= 0 {
+		return nil
+	}
+	// y² = x³ + 7, as p ≡ 3 (mod 4) the square root is (y²)^((p+1)/4).
+	y2 := new(big.Int).Exp(x, big.NewInt(3), secp256k1P)
+	y2.Add(y2, big.NewInt(7)).Mod(y2, secp256k1P)
+	e := new(big.Int).Add(secp256k1P, big.NewInt(1))
+	y := new(big.Int).Exp(y2, e.Rsh(e, 2), secp256k1P)
+	if new(big.Int).Exp(y, big.NewInt(2), secp256k1P).Cmp(y2) != 0 {
+		return nil
+	}
+	if (y.Bit(0) == 1) != odd {
+		y.Sub(secp256k1P, y)
+	}
+	return y
+}
+
+// Coordinates returns the coordinates of the point.
+func (k Secp256k1PublicKey) Coordinates() (x, y *big.Int) {
+	x = new(big.Int).SetBytes(k[1:])
+	return x, secp256k1Y(x, k[0] == 0x03)
+}
+
+// Equal checks whether the key is equal to the given one.
+func (k Secp256k1PublicKey) Equal(x crypto.PublicKey) bool {
+	o, ok := x.(Secp256k1PublicKey)
+	return ok && bytes.Equal(k, o)
+}
+
+// Uncompressed returns the uncompressed form of the key (65 bytes).
+func (k Secp256k1PublicKey) Uncompressed() []byte {
+	x, y := k.Coordinates()
+	b := make([]byte, 65)
+	b[0] = 0x04
+	x.FillBytes(b[1:33])
+	y.FillBytes(b[33:])
+	return b
+}
@@ -0,0 +1,152 @@
+package did
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ServiceEndpoint is the endpoint of a service: a URI, a map or an ordered set of URIs and maps. Exactly one of the
+// fields is set.
+// DOCS: https://www.w3.org/TR/did-core/#services
+type ServiceEndpoint struct {
+	// URI is the endpoint if it is a single URI.
+	URI string
+	// Map is the endpoint if it is a single map, its values can be arbitrary JSON.
+	Map map[string]json.RawMessage
+	// Set is the endpoint if it is an ordered set, each entry is either a URI or a map.
+	Set []ServiceEndpoint
+}
+
+// NewMapServiceEndpoint returns a service endpoint that consists of a single map. All values are marshaled to JSON.
+func NewMapServiceEndpoint(m map[string]any) (ServiceEndpoint, error) {
+	endpoint := ServiceEndpoint{Map: make(map[string]json.RawMessage, len(m))}
+	for k, v := range m {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return ServiceEndpoint{}, err
+		}
+		endpoint.Map[k] = raw
+	}
+	return endpoint, nil
+}
+
+// NewSetServiceEndpoint returns a service endpoint that consists of an ordered set of URIs and/or maps.
+func NewSetServiceEndpoint(entries ...ServiceEndpoint) ServiceEndpoint {
+	return ServiceEndpoint{Set: append([]ServiceEndpoint{}, entries...)}
+}
+
+// NewURIServiceEndpoint returns a service endpoint that consists of a single URI.
+func NewURIServiceEndpoint(uri string) ServiceEndpoint {
+	return ServiceEndpoint{URI: uri}
+}
+
+// Entries returns the entries of the set, or the endpoint itself if it is a single URI or map.
+func (e ServiceEndpoint) Entries() []ServiceEndpoint {
+	if e.IsSet() {
+		return e.Set
+	}
+	if e.IsURI() || e.IsMap() {
+		return []ServiceEndpoint{e}
+	}
+	return nil
+}
+
+// IsMap checks whether the endpoint is a single map.
+func (e ServiceEndpoint) IsMap() bool {
+	return e.Map != nil
+}
+
+// IsSet checks whether the endpoint is an ordered set.
+func (e ServiceEndpoint) IsSet() bool {
+	return e.Set != nil
+}
+
+// IsURI checks whether the endpoint is a single URI.
+func (e ServiceEndpoint) IsURI() bool {
+	return e.URI != ""
+}
+
+// Maps returns all maps of the endpoint, in order.
+func (e ServiceEndpoint) Maps() []map[string]json.RawMessage {
+	var maps []map[string]json.RawMessage
+	for _, entry := range e.Entries() {
+		if entry.IsMap() {
+			maps = append(maps, entry.Map)
+		}
+	}
+	return maps
+}
+
+func (e ServiceEndpoint) MarshalJSON() ([]byte, error) {
+	switch {
+	case e.IsSet():
+		return json.Marshal(e.Set)
+	case e.IsMap():
+		return json.Marshal(e.Map)
+	case e.IsURI():
+		return json.Marshal(e.URI)
+	default:
+		return nil, fmt.Errorf("invalid service endpoint: empty")
+	}
+}
+
+// URIs returns all URIs of the endpoint, in order.
+func (e ServiceEndpoint) URIs() []string {
+	var uris []string
+	for _, entry := range e.Entries() {
+		if entry.IsURI() {
+			uris = append(uris, entry.URI)
+		}
+	}
+	return uris
+}
+
+func (e *ServiceEndpoint) UnmarshalJSON(raw []byte) error {
+	endpoint, err := unmarshalServiceEndpoint(raw, true)
+	if err != nil {
+		return err
+	}
+	*e = endpoint
+	return nil
+}
+
+// unmarshalServiceEndpoint unmarshals a URI, a map or, if set is true, an ordered set of URIs and maps.
+func unmarshalServiceEndpoint(raw []byte, set bool) (ServiceEndpoint, error) {
+	switch raw := bytes.TrimSpace(raw); {
+	case bytes.HasPrefix(raw, []byte(`"`)):
+		var uri string
+		if err := json.Unmarshal(raw, &uri); err != nil {
+			return ServiceEndpoint{}, err
+		}
+		if uri == "" {
+			return ServiceEndpoint{}, fmt.Errorf("invalid service endpoint: empty URI")
+		}
+		return ServiceEndpoint{URI: uri}, nil
+	case bytes.HasPrefix(raw, []byte(`{`)):
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return ServiceEndpoint{}, err
+		}
+		if m == nil {
+			m = make(map[string]json.RawMessage)
+		}
+		return ServiceEndpoint{Map: m}, nil
+	case set && bytes.HasPrefix(raw, []byte(`[`)):
+		var a []json.RawMessage
+		if err := json.Unmarshal(raw, &a); err != nil {
+			return ServiceEndpoint{}, err
+		}
+		entries := make([]ServiceEndpoint, len(a))
+		for i, raw := range a {
+			entry, err := unmarshalServiceEndpoint(raw, false)
+			if err != nil {
+				return ServiceEndpoint{}, err
+			}
+			entries[i] = entry
+		}
+		return ServiceEndpoint{Set: entries}, nil
+	default:
+		return ServiceEndpoint{}, fmt.Errorf("invalid service endpoint: %s", raw)
+	}
+}
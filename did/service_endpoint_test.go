@@ -0,0 +1,99 @@
+package did_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/0x51-dev/did/did"
+	"reflect"
+	"testing"
+)
+
+func ExampleServiceEndpoint() {
+	var service did.Service
+	_ = json.Unmarshal([]byte(`{
+		"id": "did:example:123#didcomm-1",
+		"type": "DIDCommMessaging",
+		"serviceEndpoint": [
+			"https://example.com/fallback",
+			{
+				"uri": "https://example.com/path",
+				"accept": ["didcomm/v2", "didcomm/aip2;env=rfc587"],
+				"routingKeys": ["did:example:somemediator#somekey"]
+			}
+		]
+	}`), &service)
+	fmt.Println(service.ServiceEndpoint.URIs())
+	var accept []string
+	_ = json.Unmarshal(service.ServiceEndpoint.Maps()[0]["accept"], &accept)
+	fmt.Println(accept)
+	// Output:
+	// [https://example.com/fallback]
+	// [didcomm/v2 didcomm/aip2;env=rfc587]
+}
+
+func TestServiceEndpoint(t *testing.T) {
+	for _, test := range []struct {
+		raw  string
+		uris []string
+		maps int
+	}{
+		{`"https://bar.example.com"`, []string{"https://bar.example.com"}, 0},
+		{`["https://a.example.com","https://b.example.com"]`, []string{"https://a.example.com", "https://b.example.com"}, 0},
+		{`{"origins":["https://a.example.com"],"nested":{"priority":1.0}}`, nil, 1},
+		{`[{"uri":"https://a.example.com"},"https://b.example.com",{"uri":"https://c.example.com"}]`, []string{"https://b.example.com"}, 2},
+		{`["https://a.example.com"]`, []string{"https://a.example.com"}, 0},
+	} {
+		t.Run(test.raw, func(t *testing.T) {
+			var endpoint did.ServiceEndpoint
+			if err := json.Unmarshal([]byte(test.raw), &endpoint); err != nil {
+				t.Fatal(err)
+			}
+			if uris := endpoint.URIs(); !reflect.DeepEqual(uris, test.uris) {
+				t.Error(uris, test.uris)
+			}
+			if maps := endpoint.Maps(); len(maps) != test.maps {
+				t.Error(len(maps), test.maps)
+			}
+			raw, err := json.Marshal(endpoint)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var expected, actual any
+			_ = json.Unmarshal([]byte(test.raw), &expected)
+			_ = json.Unmarshal(raw, &actual)
+			if !reflect.DeepEqual(expected, actual) {
+				t.Error(string(raw), test.raw)
+			}
+		})
+	}
+
+	for _, raw := range []string{`""`, `42`, `[["https://a.example.com"]]`, `[42]`, `null`} {
+		var endpoint did.ServiceEndpoint
+		if err := json.Unmarshal([]byte(raw), &endpoint); err == nil {
+			t.Errorf("expected error for %s", raw)
+		}
+	}
+}
+
+func TestService_MarshalJSON(t *testing.T) {
+	endpoint, err := did.NewMapServiceEndpoint(map[string]any{
+		"uri":    "https://example.com/path",
+		"accept": []string{"didcomm/v2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := did.Service{
+		ID:              "did:example:123#didcomm-1",
+		Type:            []string{"DIDCommMessaging"},
+		ServiceEndpoint: did.NewSetServiceEndpoint(did.NewURIServiceEndpoint("https://example.com/fallback"), endpoint),
+	}
+	raw, err := service.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"id":"did:example:123#didcomm-1","serviceEndpoint":["https://example.com/fallback",{"accept":["didcomm/v2"],"uri":"https://example.com/path"}],"type":"DIDCommMessaging"}`
+	if string(raw) != expected {
+		t.Error(string(raw))
+	}
+}
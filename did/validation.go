@@ -0,0 +1,236 @@
+package did
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/0x51-dev/did/internal/multiformats"
+	"net/url"
+	"strings"
+)
+
+const (
+	// ContextV1 is the context of the DID v1.0 specification.
+	ContextV1 = "https://www.w3.org/ns/did/v1"
+	// ContextV11 is the context of the DID v1.1 specification.
+	ContextV11 = "https://www.w3.org/ns/did/v1.1"
+)
+
+func isURI(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != ""
+}
+
+// Validate checks the document against the conformance requirements of the DID specification and returns all
+// violations, if any.
+// DOCS: https://www.w3.org/TR/did-core/#core-properties
+func (d *Document) Validate() Violations {
+	var v Violations
+	switch {
+	case len(d.Context) == 0:
+		v.add("$['@context']", SeverityWarning, "missing @context, required for the JSON-LD representation")
+	case d.Context[0].URL != ContextV1 && d.Context[0].URL != ContextV11:
+		v.add("$['@context'][0]", SeverityError, "the first context must be %s", ContextV1)
+	}
+	if d.ID.Method == "" {
+		v.add("$.id", SeverityError, "missing id")
+	}
+	for i, aka := range d.AlsoKnownAs {
+		if !isURI(aka) {
+			v.add(fmt.Sprintf("$.alsoKnownAs[%d]", i), SeverityError, "%q is not a URI", aka)
+		}
+	}
+
+	// Embedded verification methods.
+	ids := make(map[string]string)
+	for i := range d.VerificationMethod {
+		d.validateVerificationMethod(&v, fmt.Sprintf("$.verificationMethod[%d]", i), &d.VerificationMethod[i], ids)
+	}
+	for _, r := range Relationships {
+		for i, method := range *d.relationship(r) {
+			path := fmt.Sprintf("$.%s[%d]", r, i)
+			if method, ok := method.(*VerificationMethod); ok {
+				d.validateVerificationMethod(&v, path, method, ids)
+			}
+		}
+	}
+
+	// Referenced verification methods.
+	for _, r := range Relationships {
+		for i, method := range *d.relationship(r) {
+			path := fmt.Sprintf("$.%s[%d]", r, i)
+			ref, ok := method.(*RelativeVerificationMethod)
+			if !ok {
+				continue
+			}
+			u, err := d.ID.ResolveReference(ref.RelativeURL)
+			if err != nil {
+				v.add(path, SeverityError, "invalid reference: %v", err)
+				continue
+			}
+			if u.DID().Equal(d.ID) && d.FindVerificationMethod(ref.RelativeURL) == nil {
+				v.add(path, SeverityError, "%q references a non-existent verification method", ref.RelativeURL)
+			}
+		}
+	}
+
+	// Services.
+	serviceIDs := make(map[string]string)
+	for i, service := range d.Service {
+		path := fmt.Sprintf("$.service[%d]", i)
+		id := service.ID
+		if u, err := d.ID.ResolveReference(service.ID); err == nil {
+			id = u.Normalize().String()
+		} else if !isURI(service.ID) {
+			v.add(path+".id", SeverityError, "%q is not a URI", service.ID)
+		}
+		if p, ok := serviceIDs[id]; ok {
+			v.add(path+".id", SeverityError, "duplicate id %q, also used by %s", service.ID, p)
+		} else {
+			serviceIDs[id] = path
+		}
+		if len(service.Type) == 0 {
+			v.add(path+".type", SeverityError, "missing type")
+		}
+		if len(service.ServiceEndpoint.Entries()) == 0 {
+			v.add(path+".serviceEndpoint", SeverityError, "missing serviceEndpoint")
+		}
+		for _, uri := range service.ServiceEndpoint.URIs() {
+			if !isURI(uri) {
+				v.add(path+".serviceEndpoint", SeverityError, "%q is not a URI", uri)
+			}
+		}
+	}
+	return v
+}
+
+func (d *Document) validateVerificationMethod(v *Violations, path string, method *VerificationMethod, ids map[string]string) {
+	if method.ID == "" {
+		v.add(path+".id", SeverityError, "missing id")
+	} else if u, err := d.ID.ResolveReference(method.ID); err != nil {
+		v.add(path+".id", SeverityError, "invalid id: %v", err)
+	} else {
+		id := u.Normalize().String()
+		if p, ok := ids[id]; ok {
+			v.add(path+".id", SeverityError, "duplicate id %q, also used by %s", method.ID, p)
+		} else {
+			ids[id] = path
+		}
+	}
+	if method.Type == "" {
+		v.add(path+".type", SeverityError, "missing type")
+	}
+	if method.Controller == "" {
+		v.add(path+".controller", SeverityError, "missing controller")
+	} else if _, err := ParseBareDID(method.Controller); err != nil {
+		v.add(path+".controller", SeverityError, "invalid controller: %v", err)
+	}
+	switch materials := method.materials(); len(materials) {
+	case 0:
+		v.add(path, SeverityError, "missing verification material (e.g. publicKeyJwk or publicKeyMultibase)")
+	case 1:
+		if err := validateLegacyMaterial(method); err != nil {
+			v.add(path+"."+materials[0], SeverityError, "%v", err)
+		}
+	default:
+		v.add(path, SeverityError, "verification material must be exactly one of %s", strings.Join(materials, ", "))
+	}
+	d.validateMethodType(v, path, method)
+	if jwk := method.PublicKeyJwk; jwk != nil {
+		if member := jwk.privateMember(); member != "" {
+			v.add(fmt.Sprintf("%s.publicKeyJwk.%s", path, member), SeverityError, "publicKeyJwk must not contain private key material")
+		} else if err := jwk.Validate(); err != nil {
+			v.add(path+".publicKeyJwk", SeverityError, "%v", err)
+		}
+	}
+}
+
+// validateLegacyMaterial checks the encoding of the legacy verification material formats.
+func validateLegacyMaterial(method *VerificationMethod) error {
+	switch {
+	case method.PublicKeyBase58 != "":
+		if _, err := multiformats.DecodeBase58(method.PublicKeyBase58); err != nil {
+			return fmt.Errorf("invalid publicKeyBase58: %w", err)
+		}
+	case method.PublicKeyHex != "":
+		if _, err := hex.DecodeString(method.PublicKeyHex); err != nil {
+			return fmt.Errorf("invalid publicKeyHex: %w", err)
+		}
+	case method.BlockchainAccountID != "" || method.EthereumAddress != "":
+		if _, err := method.BlockchainAccount(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseOptions are options for parsing a DID document.
+type ParseOptions struct {
+	// Strict rejects documents that violate any of the conformance requirements, see Document.Validate.
+	Strict bool
+}
+
+// validateParsed validates the parsed document if any of the options is strict.
+func (d *Document) validateParsed(options []ParseOptions) error {
+	for _, o := range options {
+		if o.Strict {
+			return d.Validate().Err()
+		}
+	}
+	return nil
+}
+
+type Severity string
+
+const (
+	// SeverityError - the document does not conform to the DID specification.
+	SeverityError Severity = "error"
+	// SeverityWarning - the document conforms to the DID specification, but might not be usable in all contexts.
+	SeverityWarning Severity = "warning"
+)
+
+// ValidationError is returned if a document does not conform to the DID specification.
+type ValidationError struct {
+	Violations Violations
+}
+
+func (e *ValidationError) Error() string {
+	var messages []string
+	for _, v := range e.Violations {
+		if v.Severity == SeverityError {
+			messages = append(messages, v.String())
+		}
+	}
+	return fmt.Sprintf("invalid DID document: %s", strings.Join(messages, "; "))
+}
+
+// Violation is a violation of a conformance requirement of the DID specification.
+type Violation struct {
+	// The JSON path of the violating property.
+	Path     string
+	Severity Severity
+	Message  string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+type Violations []Violation
+
+// Err returns a ValidationError if any of the violations is an error.
+func (v Violations) Err() error {
+	for _, violation := range v {
+		if violation.Severity == SeverityError {
+			return &ValidationError{Violations: v}
+		}
+	}
+	return nil
+}
+
+func (v *Violations) add(path string, severity Severity, format string, a ...any) {
+	*v = append(*v, Violation{
+		Path:     path,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
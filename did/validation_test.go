@@ -0,0 +1,127 @@
+package did
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDocument_Validate(t *testing.T) {
+	for i, example := range [][]byte{example1, example9, example11, example13, example20, example21, example22} {
+		doc, err := ParseDocument(example, ParseOptions{Strict: true})
+		if err != nil {
+			t.Fatalf("example %d: %v", i, err)
+		}
+		for _, v := range doc.Validate() {
+			if v.Severity == SeverityError {
+				t.Errorf("example %d: %s", i, v)
+			}
+		}
+	}
+
+	doc, _ := ParseDocument(example10)
+	if v := doc.Validate(); len(v) != 1 || v[0].Severity != SeverityWarning || v.Err() != nil {
+		t.Error(v)
+	}
+}
+
+func TestDocument_Validate_violations(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		raw   string
+		paths []string
+	}{
+		{
+			name:  "context",
+			raw:   `{"@context": ["https://w3id.org/security/suites/ed25519-2020/v1", "https://www.w3.org/ns/did/v1"], "id": "did:example:123"}`,
+			paths: []string{"$['@context'][0]"},
+		},
+		{
+			name:  "alsoKnownAs",
+			raw:   `{"@context": "https://www.w3.org/ns/did/v1", "id": "did:example:123", "alsoKnownAs": ["example.com"]}`,
+			paths: []string{"$.alsoKnownAs[0]"},
+		},
+		{
+			name: "duplicate",
+			raw: `{"@context": "https://www.w3.org/ns/did/v1", "id": "did:example:123",
+				"verificationMethod": [{"id": "#key-1", "type": "Multikey", "controller": "did:example:123", "publicKeyMultibase": "z6Mk"}],
+				"authentication": [{"id": "did:example:123#key-1", "type": "Multikey", "controller": "did:example:123", "publicKeyMultibase": "z6Mk"}]}`,
+			paths: []string{"$.authentication[0].id"},
+		},
+		{
+			name: "dangling",
+			raw: `{"@context": "https://www.w3.org/ns/did/v1", "id": "did:example:123",
+				"authentication": ["#key-1", "did:example:456#key-1"]}`,
+			paths: []string{"$.authentication[0]"},
+		},
+		{
+			name: "material",
+			raw: `{"@context": "https://www.w3.org/ns/did/v1", "id": "did:example:123",
+				"verificationMethod": [
+					{"id": "#key-1", "type": "JsonWebKey2020", "controller": "did:example:123", "publicKeyMultibase": "z6Mk", "publicKeyJwk": {"kty": "OKP", "crv": "Ed25519", "x": "abc"}},
+					{"id": "#key-2", "type": "JsonWebKey2020", "controller": "did:example:123"},
+					{"id": "#key-3", "type": "JsonWebKey2020", "controller": "did:example:123", "publicKeyJwk": {"kty": "OKP", "x": "abc"}}
+				]}`,
+			paths: []string{"$.verificationMethod[0]", "$.verificationMethod[1]", "$.verificationMethod[2].publicKeyJwk"},
+		},
+		{
+			name: "method",
+			raw: `{"@context": "https://www.w3.org/ns/did/v1", "id": "did:example:123",
+				"verificationMethod": [{"id": "#key-1", "publicKeyMultibase": "z6Mk"}, {"type": "Multikey", "controller": "did:example:123#key-1", "publicKeyMultibase": "z6Mk"}]}`,
+			paths: []string{"$.verificationMethod[0].type", "$.verificationMethod[0].controller", "$.verificationMethod[1].id", "$.verificationMethod[1].controller"},
+		},
+		{
+			name: "service",
+			raw: `{"@context": "https://www.w3.org/ns/did/v1", "id": "did:example:123",
+				"service": [
+					{"id": "#agent", "type": "DIDCommMessaging", "serviceEndpoint": "https://agent.example.com"},
+					{"id": "did:example:123#agent", "serviceEndpoint": "agent.example.com"}
+				]}`,
+			paths: []string{"$.service[1].id", "$.service[1].type", "$.service[1].serviceEndpoint"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := ParseDocument([]byte(test.raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			violations := doc.Validate()
+			var paths []string
+			for _, v := range violations {
+				if v.Severity == SeverityError {
+					paths = append(paths, v.Path)
+				}
+			}
+			if len(paths) != len(test.paths) {
+				t.Fatalf("expected %v, got %v", test.paths, violations)
+			}
+			for i, path := range paths {
+				if path != test.paths[i] {
+					t.Error(path, test.paths[i])
+				}
+			}
+
+			_, err = ParseDocument([]byte(test.raw), ParseOptions{Strict: true})
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("expected a ValidationError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDefaultResolver_Resolve_strict(t *testing.T) {
+	r := DefaultResolver{
+		Registry: Registry{
+			"example": func(_ string, _ DID, _ Resolvable, _ ResolutionOptions) ResolutionResult {
+				doc, _ := ParseDocument(example14)
+				return ResolutionResult{Document: doc}
+			},
+		},
+	}
+	if result := r.Resolve("did:example:123456789abcdefghi", ResolutionOptions{}); result.Metadata.Error != "" {
+		t.Error(result.Metadata.Error)
+	}
+	if result := r.Resolve("did:example:123456789abcdefghi", ResolutionOptions{Strict: true}); result.Metadata.Error != InvalidDIDDocumentError {
+		t.Error(result.Metadata.Error)
+	}
+}
@@ -0,0 +1,52 @@
+package did
+
+import (
+	"sync"
+)
+
+var (
+	// defaultValidators are the method-specific identifier validators used by ParseBareDID and ParseDIDURL, see
+	// RegisterValidator.
+	defaultValidators   = Validators{}
+	defaultValidatorsMu sync.RWMutex
+)
+
+// RegisterValidator registers the method-specific identifier validator of the given method, which ParseBareDID and
+// ParseDIDURL use to reject method-invalid DIDs, e.g. RegisterValidator("web", web.Validate). No validators are
+// registered by default, a nil validator removes the registration. Registering affects all subsequent parsing in the
+// program, use the Validators of a DefaultResolver to validate DIDs of a single resolver only.
+func RegisterValidator(method string, validator Validator) {
+	defaultValidatorsMu.Lock()
+	defer defaultValidatorsMu.Unlock()
+	if validator == nil {
+		delete(defaultValidators, method)
+		return
+	}
+	defaultValidators[method] = validator
+}
+
+// validateDefault validates the DID with the registered validator of its method, see RegisterValidator.
+func validateDefault(did DID) error {
+	defaultValidatorsMu.RLock()
+	validator, ok := defaultValidators[did.Method]
+	defaultValidatorsMu.RUnlock()
+	if !ok {
+		return nil
+	}
+	return validator(did)
+}
+
+// Validator validates the method-specific identifier of a DID.
+type Validator func(did DID) error
+
+// Validators maps method names to the validator of their method-specific identifiers.
+type Validators map[string]Validator
+
+// Validate validates the DID with the validator of its method, DIDs of unknown methods are always valid.
+func (v Validators) Validate(did DID) error {
+	validator, ok := v[did.Method]
+	if !ok {
+		return nil
+	}
+	return validator(did)
+}
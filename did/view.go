@@ -0,0 +1,123 @@
+package did
+
+import (
+	"encoding/json"
+)
+
+// DocumentView is a read-only view of a DID document. It holds its own copy of the document and all accessors return
+// copies, so it can be shared between goroutines, e.g. by caching resolvers. The zero value is a view of an empty
+// document, views of documents are returned by Document.View.
+type DocumentView struct {
+	doc *Document
+}
+
+// View returns a read-only view of (a deep copy of) the document.
+func (d *Document) View() DocumentView {
+	return DocumentView{doc: d.Clone()}
+}
+
+// document returns the document of the view, or an empty document for the zero value.
+func (v DocumentView) document() *Document {
+	if v.doc == nil {
+		return &Document{}
+	}
+	return v.doc
+}
+
+// AlsoKnownAs returns the alsoKnownAs property.
+func (v DocumentView) AlsoKnownAs() []string {
+	return cloneStrings(v.document().AlsoKnownAs)
+}
+
+// Context returns the @context property.
+func (v DocumentView) Context() []Context {
+	return cloneContexts(v.document().Context)
+}
+
+// Controller returns the controller property.
+func (v DocumentView) Controller() []DID {
+	return cloneDIDs(v.document().Controller)
+}
+
+// Document returns a mutable deep copy of the document.
+func (v DocumentView) Document() *Document {
+	return v.document().Clone()
+}
+
+// Extension returns the (raw) value of the extension property with the given name.
+func (v DocumentView) Extension(name string) (json.RawMessage, bool) {
+	raw, ok := v.document().Extensions[name]
+	if !ok {
+		return nil, false
+	}
+	return append(json.RawMessage(nil), raw...), true
+}
+
+// FindService returns the service identified by the given (absolute or relative) reference.
+func (v DocumentView) FindService(ref string) (Service, bool) {
+	service := v.document().FindService(ref)
+	if service == nil {
+		return Service{}, false
+	}
+	return service.Clone(), true
+}
+
+// FindVerificationMethod returns the verification method identified by the given (absolute or relative) reference.
+func (v DocumentView) FindVerificationMethod(ref string) (VerificationMethod, bool) {
+	method := v.document().FindVerificationMethod(ref)
+	if method == nil {
+		return VerificationMethod{}, false
+	}
+	return method.Clone(), true
+}
+
+// ID returns the DID of the document.
+func (v DocumentView) ID() DID {
+	return v.document().ID.Clone()
+}
+
+func (v DocumentView) MarshalJSON() ([]byte, error) {
+	return v.document().MarshalJSON()
+}
+
+// MethodsFor returns the verification methods of the given relationship, see Document.MethodsFor.
+func (v DocumentView) MethodsFor(relationship Relationship) ([]VerificationMethod, error) {
+	methods, err := v.document().MethodsFor(relationship)
+	for i, method := range methods {
+		methods[i] = method.Clone()
+	}
+	return methods, err
+}
+
+// RelationshipsOf returns the verification relationships of the verification method, see Document.RelationshipsOf.
+func (v DocumentView) RelationshipsOf(id string) []Relationship {
+	return v.document().RelationshipsOf(id)
+}
+
+// Services returns the services of the document.
+func (v DocumentView) Services() []Service {
+	services := make([]Service, len(v.document().Service))
+	for i, service := range v.document().Service {
+		services[i] = service.Clone()
+	}
+	return services
+}
+
+func (v DocumentView) String() string {
+	return v.document().String()
+}
+
+// Validate checks the document against the DID specification, see Document.Validate.
+func (v DocumentView) Validate() Violations {
+	return v.document().Validate()
+}
+
+// VerificationMethods returns the verification methods of the document, excluding the ones embedded in verification
+// relationships (see MethodsFor).
+func (v DocumentView) VerificationMethods() []VerificationMethod {
+	methods := make([]VerificationMethod, len(v.document().VerificationMethod))
+	for i, method := range v.document().VerificationMethod {
+		methods[i] = method.Clone()
+	}
+	return methods
+}
@@ -18,8 +18,10 @@ func DisableHTTPS() {
 }
 
 func Resolve(didURL string, u did2.DID, options did2.ResolutionOptions) did2.ResolutionResult {
-	if options.Accept != "application/did+jsonutils" {
-		return did2.ResolutionResult{Metadata: did2.Metadata{Error: did2.RepresentationNotSupportedError}}
+	switch options.Accept {
+	case "application/did+jsonutils", did2.MediaTypeJSON, did2.MediaTypeJSONLD:
+	default:
+		return did2.NewErrorResult(did2.RepresentationNotSupportedError, fmt.Errorf("unsupported representation: %q", options.Accept))
 	}
 	path := fmt.Sprintf("%s/.well-known/did.jsonutils", decodeURI(u.MethodIDs[0]))
 	if 1 < len(u.MethodIDs) {
@@ -33,27 +35,52 @@ func Resolve(didURL string, u did2.DID, options did2.ResolutionOptions) did2.Res
 
 	resp, err := http.Get(fmt.Sprintf("%s://%s", host, path))
 	if err != nil {
-		return did2.ResolutionResult{Metadata: did2.Metadata{Error: did2.NotFoundError}}
+		// The server is unreachable, which does not mean the DID does not exist.
+		return did2.NewErrorResult(did2.InternalError, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return did2.NewErrorResult(did2.NotFoundError, fmt.Errorf("GET %s: %s", path, resp.Status))
+	case resp.StatusCode != http.StatusOK:
+		return did2.NewErrorResult(did2.InternalError, fmt.Errorf("GET %s: %s", path, resp.Status))
 	}
 	raw, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return did2.ResolutionResult{Metadata: did2.Metadata{Error: did2.InvalidDIDError}}
+		return did2.NewErrorResult(did2.InternalError, err)
 	}
-	document, err := did2.ParseDocument(raw)
+	document, err := did2.ParseDocument(raw, did2.ParseOptions{Strict: options.Strict})
 	if err != nil {
-		return did2.ResolutionResult{Metadata: did2.Metadata{Error: did2.InvalidDIDError}}
+		return did2.NewErrorResult(did2.InvalidDIDDocumentError, err)
 	}
 
-	if document.ID.String() != didURL {
-		return did2.ResolutionResult{Metadata: did2.Metadata{Error: did2.NotFoundError}}
+	if !document.ID.Equal(u) {
+		return did2.NewErrorResult(did2.NotFoundError, fmt.Errorf("the document is of another DID: %s", document.ID))
+	}
+	if options.Accept == did2.MediaTypeJSONLD {
+		// The contexts are processed offline, the bundled contexts must suffice.
+		if err := document.ValidateJSONLD().Err(); err != nil {
+			return did2.NewErrorResult(did2.InvalidDIDDocumentError, err)
+		}
 	}
 
 	return did2.ResolutionResult{
 		Metadata: did2.Metadata{
-			ContentType: "application/did+jsonutils",
+			ContentType: options.Accept,
 		},
-		Document: document,
+		Document:         document,
+		DocumentMetadata: documentMetadata(resp.Header),
+	}
+}
+
+// documentMetadata returns the document metadata available over HTTP: the time of the last update is the modification
+// time of the did.json file, as it has no created or versioning information of its own.
+func documentMetadata(header http.Header) did2.DocumentMetadata {
+	var metadata did2.DocumentMetadata
+	if updated, err := http.ParseTime(header.Get("Last-Modified")); err == nil {
+		metadata.Updated = updated.UTC()
 	}
+	return metadata
 }
 
 func decodeURI(s string) string {
@@ -1,12 +1,16 @@
 package web
 
 import (
-	"fmt"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
 	did2 "github.com/0x51-dev/did/did"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestDecode(t *testing.T) {
@@ -31,18 +35,18 @@ func TestResolve(t *testing.T) {
 	s = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case "/.well-known/did.jsonutils":
-			u := didFromServer(s)
-			document := did2.Document{
-				Context: []string{"https://www.w3.org/ns/did/v1"},
-				ID:      u,
-				Authentication: []did2.IVerificationMethod{
-					&did2.RelativeVerificationMethod{RelativeURL: fmt.Sprintf("%s#owner", u.String())},
-				},
+			key, _, _ := ed25519.GenerateKey(rand.Reader)
+			document, err := did2.NewDocumentBuilder(didFromServer(s)).
+				KeyWithFragment("owner", key, did2.Authentication).
+				Build()
+			if err != nil {
+				t.Error(err)
 			}
 			raw, err := document.MarshalJSON()
 			if err != nil {
 				t.Error(err)
 			}
+			w.Header().Set("Last-Modified", "Mon, 10 May 2021 17:00:00 GMT")
 			if _, err := w.Write(raw); err != nil {
 				t.Error(err)
 			}
@@ -60,12 +64,124 @@ func TestResolve(t *testing.T) {
 	if result.Document.ID.String() != u.String() {
 		t.Error(result.Document.ID.String(), u.String())
 	}
+	if updated := time.Date(2021, 5, 10, 17, 0, 0, 0, time.UTC); !result.DocumentMetadata.Updated.Equal(updated) {
+		t.Error(result.DocumentMetadata.Updated, updated)
+	}
+
+	result = Resolve(u.String(), u, did2.ResolutionOptions{Accept: did2.MediaTypeJSONLD})
+	if result.Metadata.Error != "" || result.Metadata.ContentType != did2.MediaTypeJSONLD {
+		t.Error(result.Metadata)
+	}
+
+	// The CBOR representation is produced by the resolver, the method resolves the JSON representation.
+	r := did2.DefaultResolver{Registry: did2.Registry{
+		"web": func(didURL string, u did2.DID, _ did2.Resolvable, options did2.ResolutionOptions) did2.ResolutionResult {
+			return Resolve(didURL, u, options)
+		},
+	}}
+	result = r.Resolve(u.String(), did2.ResolutionOptions{Accept: did2.MediaTypeCBOR})
+	if result.Metadata.Error != "" || result.Metadata.ContentType != did2.MediaTypeCBOR {
+		t.Fatal(result.Metadata)
+	}
+	if doc, err := did2.ParseDocumentCBOR(result.DocumentStream); err != nil || !doc.ID.Equal(u) {
+		t.Error(doc, err)
+	}
+
+	// The percent-encoding of the port is not case-sensitive.
+	v, _ := did2.ParseBareDID(strings.ReplaceAll(u.String(), "%3A", "%3a"))
+	if result := Resolve(v.String(), *v, did2.ResolutionOptions{Accept: "application/did+jsonutils"}); result.Metadata.Error != "" {
+		t.Error(result.Metadata.Error)
+	}
 }
 
-func didFromServer(s *httptest.Server) did2.DID {
-	url := strings.TrimPrefix(s.URL, "http://")
-	url = strings.ReplaceAll(url, ":", "%3A")
+func TestResolve_errors(t *testing.T) {
+	DisableHTTPS()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/malformed/did.jsonutils":
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		case "/unavailable/did.jsonutils":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	u := didFromServer(s)
+	options := did2.ResolutionOptions{Accept: "application/did+jsonutils"}
+	for _, test := range []struct {
+		path string
+		err  did2.Error
+	}{
+		{"malformed", did2.InvalidDIDDocumentError},
+		{"unavailable", did2.InternalError},
+		{"missing", did2.NotFoundError},
+	} {
+		v, _ := did2.ParseBareDID(u.String() + ":" + test.path)
+		result := Resolve(v.String(), *v, options)
+		if result.Metadata.Error != test.err {
+			t.Error(test.path, result.Metadata.Error)
+		}
+		if err := result.Metadata.Err(); !errors.Is(err, test.err) || result.Metadata.ProblemDetails.Cause == nil {
+			t.Error(test.path, err)
+		}
+	}
+
+	// The server is down.
+	s.Close()
+	result := Resolve(u.String(), u, options)
+	var urlErr *url.Error
+	if err := result.Metadata.Err(); !errors.Is(err, did2.InternalError) || !errors.As(err, &urlErr) {
+		t.Error(err)
+	}
+	if result := Resolve(u.String(), u, did2.ResolutionOptions{}); !errors.Is(result.Metadata.Err(), did2.RepresentationNotSupportedError) {
+		t.Error(result.Metadata.Err())
+	}
+}
 
-	u, _ := did2.ParseDID(fmt.Sprintf("did:web:%s", url))
+func didFromServer(s *httptest.Server) did2.DID {
+	u, _ := did2.NewURLBuilder("web").MethodID(strings.TrimPrefix(s.URL, "http://")).BuildDID()
 	return *u
 }
+
+func TestValidate(t *testing.T) {
+	if _, err := did2.ParseBareDID("did:web:-example.com"); err != nil {
+		t.Fatal("expected no validation without registration:", err)
+	}
+	did2.RegisterValidator("web", Validate)
+	t.Cleanup(func() { did2.RegisterValidator("web", nil) })
+
+	for _, test := range []string{
+		"did:web:w3c-ccg.github.io",
+		"did:web:w3c-ccg.github.io:user:alice",
+		"did:web:example.com%3A3000:user:alice",
+		"did:web:example.com:path:some%2Bsubpath",
+		"did:web:127.0.0.1%3A8443",
+		"did:web:%5B%3A%3A1%5D%3A8443",
+	} {
+		if _, err := did2.ParseBareDID(test); err != nil {
+			t.Errorf("ParseBareDID(%q): %v", test, err)
+		}
+	}
+	for _, test := range []string{
+		"did:web:-example.com",
+		"did:web:example..com",
+		"did:web:example.com%3A",
+		"did:web:example.com%3A99999",
+		"did:web:exa_mple.com",
+		"did:web:example.com:user::alice",
+		"did:web:example.com:%2E%2E",
+		"did:web:example.com:a%2Fb",
+		"did:web:%5B::1",
+	} {
+		_, err := did2.ParseBareDID(test)
+		var parseErr *did2.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("ParseBareDID(%q): expected a ParseError, got %v", test, err)
+			continue
+		}
+		if parseErr.Production != "method-specific-id" || parseErr.Offset != 8 {
+			t.Error(parseErr)
+		}
+	}
+}
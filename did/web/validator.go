@@ -0,0 +1,89 @@
+package web
+
+import (
+	"fmt"
+	did2 "github.com/0x51-dev/did/did"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Validate validates the method-specific identifier of a did:web DID: a (percent-encoded) host with an optional port,
+// followed by an optional path. It is not registered by default, see did.RegisterValidator.
+// DOCS: https://w3c-ccg.github.io/did-method-web/#method-specific-identifier
+func Validate(u did2.DID) error {
+	if len(u.MethodIDs) == 0 {
+		return fmt.Errorf("missing host")
+	}
+	host, err := url.PathUnescape(u.MethodIDs[0])
+	if err != nil {
+		return fmt.Errorf("invalid host: %w", err)
+	}
+	if err := validateHost(host); err != nil {
+		return err
+	}
+	for _, segment := range u.MethodIDs[1:] {
+		segment, err := url.PathUnescape(segment)
+		if err != nil {
+			return fmt.Errorf("invalid path: %w", err)
+		}
+		if segment == "" || segment == "." || segment == ".." || strings.Contains(segment, "/") {
+			return fmt.Errorf("invalid path segment: %q", segment)
+		}
+	}
+	return nil
+}
+
+func validateHost(host string) error {
+	if strings.HasPrefix(host, "[") {
+		// IPv6 literal.
+		end := strings.Index(host, "]")
+		if end == -1 || net.ParseIP(host[1:end]) == nil {
+			return fmt.Errorf("invalid host: %s", host)
+		}
+		if port := host[end+1:]; port != "" {
+			if !strings.HasPrefix(port, ":") {
+				return fmt.Errorf("invalid host: %s", host)
+			}
+			return validatePort(port[1:])
+		}
+		return nil
+	}
+	if i := strings.LastIndex(host, ":"); i != -1 {
+		if err := validatePort(host[i+1:]); err != nil {
+			return err
+		}
+		host = host[:i]
+	}
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+	return validateHostname(host)
+}
+
+func validateHostname(hostname string) error {
+	if hostname == "" || 253 < len(hostname) {
+		return fmt.Errorf("invalid host: %q", hostname)
+	}
+	for _, label := range strings.Split(hostname, ".") {
+		if label == "" || 63 < len(label) || label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("invalid host: %s", hostname)
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-') {
+				return fmt.Errorf("invalid host: %s", hostname)
+			}
+		}
+	}
+	return nil
+}
+
+func validatePort(port string) error {
+	p, err := strconv.Atoi(port)
+	if err != nil || p < 1 || 65535 < p {
+		return fmt.Errorf("invalid port: %s", port)
+	}
+	return nil
+}
@@ -0,0 +1,341 @@
+package cbor
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"unicode/utf8"
+)
+
+// maxDepth is the maximum nesting depth of arrays, maps and tags.
+const maxDepth = 256
+
+// indefinite is the additional information of indefinite-length items, and of the "break" stop code.
+const indefinite = 31
+
+var errUnexpectedEnd = errors.New("cbor: unexpected end of data")
+
+type decoder struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+// Unmarshal decodes a single CBOR data item to a JSON value: maps (with text string keys) are decoded as
+// map[string]any, arrays as []any, text strings as string, all numbers (including bignums and decimal fractions) as
+// json.Number, and false, true, null and undefined as bool and nil. Indefinite lengths are accepted, the tags of other
+// data items are ignored. Byte strings and other simple values have no JSON equivalent and are rejected.
+// DOCS: https://www.rfc-editor.org/rfc/rfc8949#section-6.1
+func Unmarshal(data []byte) (any, error) {
+	d := decoder{data: data}
+	v, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, fmt.Errorf("cbor: %d bytes of trailing data", len(d.data)-d.pos)
+	}
+	return v, nil
+}
+
+func (d *decoder) decode() (any, error) {
+	major, info, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case majorUnsigned:
+		return json.Number(strconv.FormatUint(arg, 10)), nil
+	case majorNegative:
+		n := new(big.Int).SetUint64(arg)
+		return json.Number(n.Neg(n).Sub(n, big.NewInt(1)).String()), nil
+	case majorBytes:
+		return nil, fmt.Errorf("cbor: byte strings are not supported")
+	case majorText:
+		b, err := d.text(info, arg)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case majorArray:
+		if err := d.enter(); err != nil {
+			return nil, err
+		}
+		defer d.leave()
+		var a []any
+		if info != indefinite {
+			if err := d.check(arg); err != nil {
+				return nil, err
+			}
+			a = make([]any, 0, arg)
+		}
+		for i := uint64(0); info == indefinite || i < arg; i++ {
+			if info == indefinite && d.isBreak() {
+				break
+			}
+			v, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		if a == nil {
+			a = []any{}
+		}
+		return a, nil
+	case majorMap:
+		if err := d.enter(); err != nil {
+			return nil, err
+		}
+		defer d.leave()
+		if info != indefinite {
+			if err := d.check(arg); err != nil {
+				return nil, err
+			}
+		}
+		m := make(map[string]any)
+		for i := uint64(0); info == indefinite || i < arg; i++ {
+			if info == indefinite && d.isBreak() {
+				break
+			}
+			k, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("cbor: map key %v is not a text string", k)
+			}
+			if _, ok := m[key]; ok {
+				return nil, fmt.Errorf("cbor: duplicate map key %q", key)
+			}
+			if m[key], err = d.decode(); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case majorTag:
+		// Tags nest like arrays and maps, they count toward the maximum depth.
+		if err := d.enter(); err != nil {
+			return nil, err
+		}
+		defer d.leave()
+		switch arg {
+		case tagPositiveBignum, tagNegativeBignum:
+			n, err := d.bignum()
+			if err != nil {
+				return nil, err
+			}
+			if arg == tagNegativeBignum {
+				n.Neg(n).Sub(n, big.NewInt(1))
+			}
+			return json.Number(n.String()), nil
+		case tagDecimalFraction:
+			return d.decimalFraction()
+		default:
+			return d.decode()
+		}
+	default:
+		return d.simple(info, arg)
+	}
+}
+
+// head reads the initial byte and argument of a data item.
+func (d *decoder) head() (major byte, info byte, arg uint64, err error) {
+	if len(d.data) <= d.pos {
+		return 0, 0, 0, errUnexpectedEnd
+	}
+	major, info = d.data[d.pos]>>5, d.data[d.pos]&0x1f
+	d.pos++
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info == indefinite:
+		switch major {
+		case majorBytes, majorText, majorArray, majorMap:
+			return major, info, 0, nil
+		case majorSimple:
+			return 0, 0, 0, fmt.Errorf("cbor: unexpected break")
+		}
+		return 0, 0, 0, fmt.Errorf("cbor: invalid indefinite length of major type %d", major)
+	case 27 < info:
+		return 0, 0, 0, fmt.Errorf("cbor: invalid additional information %d", info)
+	}
+	n := 1 << (info - 24)
+	if len(d.data)-d.pos < n {
+		return 0, 0, 0, errUnexpectedEnd
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	switch n {
+	case 1:
+		arg = uint64(b[0])
+	case 2:
+		arg = uint64(binary.BigEndian.Uint16(b))
+	case 4:
+		arg = uint64(binary.BigEndian.Uint32(b))
+	default:
+		arg = binary.BigEndian.Uint64(b)
+	}
+	return major, info, arg, nil
+}
+
+// text reads the content of a text string, of which the head is already read.
+func (d *decoder) text(info byte, arg uint64) ([]byte, error) {
+	b, err := d.chunks(majorText, info, arg)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(b) {
+		return nil, fmt.Errorf("cbor: invalid UTF-8 in text string")
+	}
+	return b, nil
+}
+
+// chunks reads the content of a (possibly indefinite-length) byte or text string, of which the head is already read.
+func (d *decoder) chunks(major, info byte, arg uint64) ([]byte, error) {
+	if info != indefinite {
+		if err := d.check(arg); err != nil {
+			return nil, err
+		}
+		b := d.data[d.pos : d.pos+int(arg)]
+		d.pos += int(arg)
+		return b, nil
+	}
+	var b []byte
+	for !d.isBreak() {
+		m, i, n, err := d.head()
+		if err != nil {
+			return nil, err
+		}
+		if m != major || i == indefinite {
+			return nil, fmt.Errorf("cbor: invalid chunk of indefinite-length string")
+		}
+		chunk, err := d.chunks(major, i, n)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, chunk...)
+	}
+	return b, nil
+}
+
+// bignum reads the byte string content of a bignum.
+func (d *decoder) bignum() (*big.Int, error) {
+	major, info, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	if major != majorBytes {
+		return nil, fmt.Errorf("cbor: bignum is not a byte string")
+	}
+	b, err := d.chunks(majorBytes, info, arg)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// decimalFraction reads the [exponent, mantissa] content of a decimal fraction.
+func (d *decoder) decimalFraction() (any, error) {
+	v, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+	a, ok := v.([]any)
+	if !ok || len(a) != 2 {
+		return nil, fmt.Errorf("cbor: decimal fraction is not an array of two integers")
+	}
+	var parts [2]*big.Int
+	for i, item := range a {
+		n, ok := item.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("cbor: decimal fraction is not an array of two integers")
+		}
+		if parts[i], ok = new(big.Int).SetString(string(n), 10); !ok {
+			return nil, fmt.Errorf("cbor: decimal fraction is not an array of two integers")
+		}
+	}
+	return json.Number(parts[1].String() + "e" + parts[0].String()), nil
+}
+
+// simple decodes the simple values and floats.
+func (d *decoder) simple(info byte, arg uint64) (any, error) {
+	var f float64
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		f = halfToFloat(uint16(arg))
+	case 26:
+		f = float64(math.Float32frombits(uint32(arg)))
+	case 27:
+		f = math.Float64frombits(arg)
+	default:
+		return nil, fmt.Errorf("cbor: unsupported simple value %d", arg)
+	}
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, fmt.Errorf("cbor: %v is not a JSON number", f)
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+}
+
+// halfToFloat converts a half precision float.
+// SOURCE: https://www.rfc-editor.org/rfc/rfc8949#appendix-D
+func halfToFloat(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	mantissa := float64(h & 0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mantissa, -24)
+	case 31:
+		if mantissa == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mantissa+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
+
+// isBreak consumes the "break" stop code, if it is next.
+func (d *decoder) isBreak() bool {
+	if d.pos < len(d.data) && d.data[d.pos] == majorSimple<<5|indefinite {
+		d.pos++
+		return true
+	}
+	return false
+}
+
+// check checks that the remaining data can contain n bytes (or items), so that no unreasonable lengths are allocated.
+func (d *decoder) check(n uint64) error {
+	if uint64(len(d.data)-d.pos) < n {
+		return errUnexpectedEnd
+	}
+	return nil
+}
+
+func (d *decoder) enter() error {
+	if d.depth++; maxDepth < d.depth {
+		return fmt.Errorf("cbor: exceeded maximum nesting depth of %d", maxDepth)
+	}
+	return nil
+}
+
+func (d *decoder) leave() {
+	d.depth--
+}
@@ -0,0 +1,148 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshal(t *testing.T) {
+	// SOURCE: https://www.rfc-editor.org/rfc/rfc8949#appendix-A
+	for _, test := range []struct {
+		cbor  string
+		value any
+	}{
+		{"00", json.Number("0")},
+		{"1bffffffffffffffff", json.Number("18446744073709551615")},
+		{"c249010000000000000000", json.Number("18446744073709551616")},
+		{"3bffffffffffffffff", json.Number("-18446744073709551616")},
+		{"c349010000000000000000", json.Number("-18446744073709551617")},
+		{"3903e7", json.Number("-1000")},
+		{"f90000", json.Number("0")},
+		{"f93c00", json.Number("1")},
+		{"fb3ff199999999999a", json.Number("1.1")},
+		{"f93e00", json.Number("1.5")},
+		{"f97bff", json.Number("65504")},
+		{"fa47c35000", json.Number("100000")},
+		{"f90001", json.Number("5.960464477539063e-08")},
+		{"f9c400", json.Number("-4")},
+		{"c48221196ab3", json.Number("27315e-2")},
+		{"f4", false},
+		{"f5", true},
+		{"f6", nil},
+		{"f7", nil},
+		{"62c3bc", "ü"},
+		{"80", []any{}},
+		{"a26161016162820203", map[string]any{"a": json.Number("1"), "b": []any{json.Number("2"), json.Number("3")}}},
+		// Indefinite lengths.
+		{"7f657374726561646d696e67ff", "streaming"},
+		{"9f018202039f0405ffff", []any{json.Number("1"), []any{json.Number("2"), json.Number("3")}, []any{json.Number("4"), json.Number("5")}}},
+		{"9fff", []any{}},
+		{"bf61610161629f0203ffff", map[string]any{"a": json.Number("1"), "b": []any{json.Number("2"), json.Number("3")}}},
+		// Other tags are ignored.
+		{"c074323031332d30332d32315432303a30343a30305a", "2013-03-21T20:04:00Z"},
+		{"d82076687474703a2f2f7777772e6578616d706c652e636f6d", "http://www.example.com"},
+	} {
+		raw, err := hex.DecodeString(test.cbor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, err := Unmarshal(raw)
+		if err != nil {
+			t.Fatal(test.cbor, err)
+		}
+		if !reflect.DeepEqual(v, test.value) {
+			t.Errorf("%s: expected %#v, got %#v", test.cbor, test.value, v)
+		}
+	}
+}
+
+func TestUnmarshal_invalid(t *testing.T) {
+	for _, test := range []string{
+		"",
+		"18",                 // Missing argument.
+		"62c3",               // Missing content.
+		"0000",               // Trailing data.
+		"4161",               // Byte string.
+		"a1016161",           // Integer key.
+		"a2616101616102",     // Duplicate key.
+		"62c328",             // Invalid UTF-8.
+		"f97c00",             // Infinity.
+		"f97e00",             // NaN.
+		"f0",                 // Unassigned simple value.
+		"ff",                 // Unexpected break.
+		"1f",                 // Indefinite integer.
+		"1c",                 // Reserved additional information.
+		"7f6161",             // Missing break.
+		"7f4161ff",           // Byte string chunk of text string.
+		"9bffffffffffffffff", // Unreasonable length.
+		"c26161",             // Bignum of text string.
+		"c48101",             // Decimal fraction of one item.
+	} {
+		raw, err := hex.DecodeString(test)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Unmarshal(raw); err == nil {
+			t.Errorf("%s: expected an error", test)
+		}
+	}
+}
+
+func TestUnmarshal_depth(t *testing.T) {
+	raw := make([]byte, maxDepth+1)
+	for i := range raw {
+		raw[i] = 0x81
+	}
+	if _, err := Unmarshal(append(raw, 0x00)); err == nil {
+		t.Error("expected an error")
+	}
+	if _, err := Unmarshal(append(raw[1:], 0x00)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnmarshal_tagDepth(t *testing.T) {
+	// Deeply nested tags must not overflow the stack.
+	raw := bytes.Repeat([]byte{0xc6}, 1<<20)
+	if _, err := Unmarshal(append(raw, 0xa0)); err == nil {
+		t.Error("expected an error")
+	}
+	if _, err := Unmarshal(append(raw[:maxDepth], 0xa0)); err == nil {
+		t.Error("expected an error")
+	}
+	if _, err := Unmarshal(append(raw[:maxDepth-1], 0xa0)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	var value any
+	d := json.NewDecoder(strings.NewReader(`{"id":"did:example:123","n":[0,-1,1.5,0.1,1e400,-18446744073709551617],"o":{"":null,"t":true}}`))
+	d.UseNumber()
+	if err := d.Decode(&value); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := Unmarshal(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := decoded.(map[string]any)["n"].([]any)
+	if n[3] != json.Number("0.1") || n[4] != json.Number("1e400") {
+		t.Error(n)
+	}
+	again, err := Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(raw) != hex.EncodeToString(again) {
+		t.Errorf("expected %x, got %x", raw, again)
+	}
+}
@@ -0,0 +1,247 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Major types.
+// DOCS: https://www.rfc-editor.org/rfc/rfc8949#section-3.1
+const (
+	majorUnsigned = 0
+	majorNegative = 1
+	majorBytes    = 2
+	majorText     = 3
+	majorArray    = 4
+	majorMap      = 5
+	majorTag      = 6
+	majorSimple   = 7
+)
+
+// Tags of numbers that do not fit in the basic types.
+// DOCS: https://www.rfc-editor.org/rfc/rfc8949#section-3.4.3
+const (
+	tagPositiveBignum  = 2
+	tagNegativeBignum  = 3
+	tagDecimalFraction = 4
+)
+
+// Marshal encodes a JSON value (nil, bool, string, json.Number, float64, []any or map[string]any) with the
+// deterministic encoding: the shortest form of all arguments and numbers, definite lengths, and map keys sorted by
+// their encoding. Integral numbers are encoded as integers (or bignums), other numbers as the shortest float that
+// preserves their value, or as decimal fraction if there is none.
+// DOCS: https://www.rfc-editor.org/rfc/rfc8949#section-4.2.1
+func Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encode(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encode(buf *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteByte(majorSimple<<5 | 22)
+	case bool:
+		if v {
+			buf.WriteByte(majorSimple<<5 | 21)
+		} else {
+			buf.WriteByte(majorSimple<<5 | 20)
+		}
+	case string:
+		writeHead(buf, majorText, uint64(len(v)))
+		buf.WriteString(v)
+	case json.Number:
+		return encodeNumber(buf, string(v))
+	case float64:
+		return encodeNumber(buf, strconv.FormatFloat(v, 'g', -1, 64))
+	case []any:
+		writeHead(buf, majorArray, uint64(len(v)))
+		for _, item := range v {
+			if err := encode(buf, item); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		entries := make([][2][]byte, 0, len(v))
+		for k, item := range v {
+			var key, value bytes.Buffer
+			writeHead(&key, majorText, uint64(len(k)))
+			key.WriteString(k)
+			if err := encode(&value, item); err != nil {
+				return err
+			}
+			entries = append(entries, [2][]byte{key.Bytes(), value.Bytes()})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return bytes.Compare(entries[i][0], entries[j][0]) < 0
+		})
+		writeHead(buf, majorMap, uint64(len(v)))
+		for _, entry := range entries {
+			buf.Write(entry[0])
+			buf.Write(entry[1])
+		}
+	default:
+		return fmt.Errorf("cbor: unsupported type %T", v)
+	}
+	return nil
+}
+
+// maxIntegerDigits is the maximum number of digits of integers that are encoded as (big) integers, larger integers
+// are encoded as floats or decimal fractions, e.g. 1e999999999 does not become a bignum of 400 MB.
+const maxIntegerDigits = 64
+
+// encodeNumber encodes a JSON number literal.
+func encodeNumber(buf *bytes.Buffer, literal string) error {
+	mantissa, exponent, err := decimal(literal)
+	if err != nil {
+		return err
+	}
+	if 0 <= exponent && int64(len(mantissa.String()))+exponent <= maxIntegerDigits {
+		encodeInt(buf, mantissa.Mul(mantissa, new(big.Int).Exp(big.NewInt(10), big.NewInt(exponent), nil)))
+		return nil
+	}
+	if f, err := strconv.ParseFloat(literal, 64); err == nil {
+		m, e, err := decimal(strconv.FormatFloat(f, 'g', -1, 64))
+		if err == nil && m.Cmp(mantissa) == 0 && e == exponent {
+			encodeFloat(buf, f)
+			return nil
+		}
+	}
+	// Decimal fraction: [exponent, mantissa].
+	writeHead(buf, majorTag, tagDecimalFraction)
+	writeHead(buf, majorArray, 2)
+	encodeInt(buf, big.NewInt(exponent))
+	encodeInt(buf, mantissa)
+	return nil
+}
+
+// decimal returns the mantissa and (base 10) exponent of the number literal, normalized so that the mantissa has no
+// trailing zeros.
+func decimal(literal string) (*big.Int, int64, error) {
+	if !json.Valid([]byte(literal)) || (literal[0] != '-' && (literal[0] < '0' || '9' < literal[0])) {
+		return nil, 0, fmt.Errorf("cbor: invalid number %q", literal)
+	}
+	digits, exp, _ := strings.Cut(strings.ToLower(literal), "e")
+	var exponent int64
+	if exp != "" {
+		e, err := strconv.ParseInt(exp, 10, 32)
+		if err != nil {
+			return nil, 0, fmt.Errorf("cbor: number out of range %q", literal)
+		}
+		exponent = e
+	}
+	if integer, fraction, ok := strings.Cut(digits, "."); ok {
+		digits = integer + fraction
+		exponent -= int64(len(fraction))
+	}
+	negative := strings.HasPrefix(digits, "-")
+	digits = strings.TrimLeft(strings.TrimPrefix(digits, "-"), "0")
+	trimmed := strings.TrimRight(digits, "0")
+	exponent += int64(len(digits) - len(trimmed))
+	if trimmed == "" {
+		return new(big.Int), 0, nil
+	}
+	mantissa, _ := new(big.Int).SetString(trimmed, 10)
+	if negative {
+		mantissa.Neg(mantissa)
+	}
+	return mantissa, exponent, nil
+}
+
+func encodeInt(buf *bytes.Buffer, i *big.Int) {
+	if i.Sign() >= 0 {
+		if i.IsUint64() {
+			writeHead(buf, majorUnsigned, i.Uint64())
+			return
+		}
+		writeHead(buf, majorTag, tagPositiveBignum)
+		b := i.Bytes()
+		writeHead(buf, majorBytes, uint64(len(b)))
+		buf.Write(b)
+		return
+	}
+	// Negative integers are encoded as -1 - n.
+	n := new(big.Int).Neg(i)
+	n.Sub(n, big.NewInt(1))
+	if n.IsUint64() {
+		writeHead(buf, majorNegative, n.Uint64())
+		return
+	}
+	writeHead(buf, majorTag, tagNegativeBignum)
+	b := n.Bytes()
+	writeHead(buf, majorBytes, uint64(len(b)))
+	buf.Write(b)
+}
+
+// encodeFloat encodes the float in the shortest of the half, single or double precision formats that preserves its
+// value.
+func encodeFloat(buf *bytes.Buffer, f float64) {
+	if f32 := float32(f); float64(f32) == f {
+		if h, ok := float16(f32); ok {
+			buf.WriteByte(majorSimple<<5 | 25)
+			_ = binary.Write(buf, binary.BigEndian, h)
+			return
+		}
+		buf.WriteByte(majorSimple<<5 | 26)
+		_ = binary.Write(buf, binary.BigEndian, math.Float32bits(f32))
+		return
+	}
+	buf.WriteByte(majorSimple<<5 | 27)
+	_ = binary.Write(buf, binary.BigEndian, math.Float64bits(f))
+}
+
+// float16 returns the half precision representation of the (finite) float, if it is exact.
+func float16(f float32) (uint16, bool) {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int((bits>>23)&0xff) - 127
+	mantissa := bits & 0x7fffff
+	switch {
+	case bits&0x7fffffff == 0:
+		return sign, true
+	case -14 <= exp && exp <= 15:
+		if mantissa&0x1fff != 0 {
+			return 0, false
+		}
+		return sign | uint16(exp+15)<<10 | uint16(mantissa>>13), true
+	case -24 <= exp && exp < -14:
+		// Subnormal: the value is m * 2^-24.
+		shift := uint(-1 - exp)
+		full := mantissa | 1<<23
+		if full&(1<<shift-1) != 0 {
+			return 0, false
+		}
+		return sign | uint16(full>>shift), true
+	default:
+		return 0, false
+	}
+}
+
+// writeHead writes the initial byte and argument of a data item, in its shortest form.
+func writeHead(buf *bytes.Buffer, major byte, arg uint64) {
+	switch {
+	case arg < 24:
+		buf.WriteByte(major<<5 | byte(arg))
+	case arg <= math.MaxUint8:
+		buf.WriteByte(major<<5 | 24)
+		buf.WriteByte(byte(arg))
+	case arg <= math.MaxUint16:
+		buf.WriteByte(major<<5 | 25)
+		_ = binary.Write(buf, binary.BigEndian, uint16(arg))
+	case arg <= math.MaxUint32:
+		buf.WriteByte(major<<5 | 26)
+		_ = binary.Write(buf, binary.BigEndian, uint32(arg))
+	default:
+		buf.WriteByte(major<<5 | 27)
+		_ = binary.Write(buf, binary.BigEndian, arg)
+	}
+}
@@ -0,0 +1,79 @@
+package cbor
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshal(t *testing.T) {
+	// SOURCE: https://www.rfc-editor.org/rfc/rfc8949#appendix-A
+	for _, test := range []struct {
+		value any
+		cbor  string
+	}{
+		{json.Number("0"), "00"},
+		{json.Number("23"), "17"},
+		{json.Number("24"), "1818"},
+		{json.Number("1000"), "1903e8"},
+		{json.Number("1000000"), "1a000f4240"},
+		{json.Number("1000000000000"), "1b000000e8d4a51000"},
+		{json.Number("18446744073709551615"), "1bffffffffffffffff"},
+		{json.Number("18446744073709551616"), "c249010000000000000000"},
+		{json.Number("-18446744073709551616"), "3bffffffffffffffff"},
+		{json.Number("-18446744073709551617"), "c349010000000000000000"},
+		{json.Number("-1"), "20"},
+		{json.Number("-1000"), "3903e7"},
+		{json.Number("1.0"), "01"},
+		{json.Number("1e3"), "1903e8"},
+		{json.Number("1.5"), "f93e00"},
+		{json.Number("-4.1"), "fbc010666666666666"},
+		{json.Number("65504.5"), "fa477fe080"},
+		{json.Number("100000.5"), "fa47c35040"},
+		{json.Number("1e20"), "c249056bc75e2d63100000"},
+		{json.Number("5.960464477539063e-8"), "f90001"},
+		{json.Number("0.00006103515625"), "f90400"},
+		{json.Number("-0.0"), "00"},
+		{json.Number("273.15"), "fb4071126666666666"},
+		{json.Number("0.1e-400"), "c48239019001"},
+		{json.Number("1e400"), "c48219019001"},
+		{json.Number("100e999999"), "c4821a000f424101"},
+		{false, "f4"},
+		{true, "f5"},
+		{nil, "f6"},
+		{"", "60"},
+		{"a", "6161"},
+		{"IETF", "6449455446"},
+		{"\"\\", "62225c"},
+		{"ü", "62c3bc"},
+		{[]any{}, "80"},
+		{[]any{json.Number("1"), []any{json.Number("2"), json.Number("3")}}, "8201820203"},
+		{map[string]any{}, "a0"},
+		{map[string]any{"a": json.Number("1"), "b": []any{json.Number("2"), json.Number("3")}}, "a26161016162820203"},
+		// Keys are sorted by their encoding: shorter keys first.
+		{map[string]any{"aa": true, "b": false}, "a26162f4626161f5"},
+	} {
+		raw, err := Marshal(test.value)
+		if err != nil {
+			t.Fatal(test.value, err)
+		}
+		if h := hex.EncodeToString(raw); h != test.cbor {
+			t.Errorf("%v: expected %s, got %s", test.value, test.cbor, h)
+		}
+	}
+}
+
+func TestMarshal_invalid(t *testing.T) {
+	for _, value := range []any{
+		json.Number("1/2"),
+		json.Number("0x10"),
+		json.Number("1e99999999999"),
+		json.Number(""),
+		[]byte{0},
+		map[string]any{"a": struct{}{}},
+	} {
+		if _, err := Marshal(value); err == nil {
+			t.Errorf("%v: expected an error", value)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package jsonld
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type compactor struct {
+	options Options
+}
+
+// Compact compacts the expanded JSON-LD document with the given context: IRIs are replaced by the terms of the
+// context, and single values are not wrapped in arrays unless the term has a @set or @list container. The context is
+// added as @context to the result, unless it is nil.
+// DOCS: https://www.w3.org/TR/json-ld11-api/#compaction-algorithm
+func Compact(input any, context any, options Options) (map[string]any, error) {
+	ctx, err := Parse(context, options.Loader)
+	if err != nil {
+		return nil, err
+	}
+	c := compactor{options: options}
+	compacted, err := c.compact(ctx, "", input)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]any
+	switch v := compacted.(type) {
+	case map[string]any:
+		result = v
+	case []any:
+		result = make(map[string]any)
+		if len(v) != 0 {
+			result[ctx.alias("@graph")] = v
+		}
+	default:
+		result = make(map[string]any)
+	}
+	if context != nil {
+		result["@context"] = context
+	}
+	return result, nil
+}
+
+func (c *compactor) compact(ctx *Context, property string, element any) (any, error) {
+	switch element := element.(type) {
+	case []any:
+		result := make([]any, 0, len(element))
+		for _, item := range element {
+			v, err := c.compact(ctx, property, item)
+			if err != nil {
+				return nil, err
+			}
+			if v != nil {
+				result = append(result, v)
+			}
+		}
+		t := ctx.terms[property]
+		if len(result) == 1 && property != "@graph" && property != "@set" && !t.hasContainer("@set") && !t.hasContainer("@list") {
+			return result[0], nil
+		}
+		return result, nil
+	case map[string]any:
+		return c.compactObject(ctx, property, element)
+	default:
+		return element, nil
+	}
+}
+
+func (c *compactor) compactObject(ctx *Context, property string, element map[string]any) (any, error) {
+	scoped := ctx.terms[property]
+	_, isValue := element["@value"]
+	_, hasID := element["@id"]
+	isReference := hasID && len(element) == 1
+	if ctx.previous != nil && !isValue && !isReference {
+		ctx = ctx.previous
+	}
+	var err error
+	if scoped != nil && scoped.hasContext {
+		if ctx, err = ctx.process(scoped.context, processing{loader: c.options.Loader, overrideProtected: true, propagate: true}); err != nil {
+			return nil, err
+		}
+	}
+	if isValue || isReference {
+		if v, ok := ctx.compactValue(property, element); ok {
+			return v, nil
+		}
+	}
+	if list, ok := element["@list"]; ok && ctx.terms[property].hasContainer("@list") {
+		return c.compact(ctx, property, list)
+	}
+
+	// Apply the type-scoped contexts, which do not propagate to nested node objects.
+	typeCtx := ctx
+	var types []string
+	for _, t := range asArray(element["@type"]) {
+		if s, ok := t.(string); ok {
+			types = append(types, typeCtx.compactIRI(s, nil, true))
+		}
+	}
+	compactedTypes := append([]string(nil), types...)
+	sort.Strings(types)
+	for _, typ := range types {
+		if t := typeCtx.terms[typ]; t != nil && t.hasContext {
+			if ctx, err = ctx.process(t.context, processing{loader: c.options.Loader, propagate: false}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := make(map[string]any)
+	for _, key := range sortedKeys(element) {
+		value := element[key]
+		switch key {
+		case "@id":
+			s, _ := value.(string)
+			result[ctx.alias("@id")] = ctx.compactIRI(s, nil, false)
+			continue
+		case "@type":
+			alias := ctx.alias("@type")
+			if _, ok := value.([]any); !ok || (len(compactedTypes) == 1 && !ctx.terms[alias].hasContainer("@set")) {
+				if 0 < len(compactedTypes) {
+					result[alias] = compactedTypes[0]
+				}
+				continue
+			}
+			var a []any
+			for _, t := range compactedTypes {
+				a = append(a, t)
+			}
+			result[alias] = a
+			continue
+		case "@value", "@language", "@index", "@direction":
+			result[ctx.alias(key)] = value
+			continue
+		case "@graph", "@included", "@list":
+			v, err := c.compact(ctx, key, value)
+			if err != nil {
+				return nil, err
+			}
+			result[ctx.alias(key)] = asArray(v)
+			continue
+		}
+		if isKeyword(key) {
+			return nil, fmt.Errorf("unsupported keyword: %s", key)
+		}
+
+		items := asArray(value)
+		if len(items) == 0 {
+			result[ctx.compactIRI(key, nil, true)] = []any{}
+			continue
+		}
+		for _, item := range items {
+			name := ctx.compactIRI(key, item, true)
+			t := ctx.terms[name]
+			v, err := c.compact(ctx, name, item)
+			if err != nil {
+				return nil, err
+			}
+			add(result, name, v, t.hasContainer("@set") || t.hasContainer("@list"))
+		}
+	}
+	return result, nil
+}
+
+// compactValue compacts a value object or node reference to a scalar, if the term definition of the property allows
+// it.
+// DOCS: https://www.w3.org/TR/json-ld11-api/#value-compaction
+func (c *Context) compactValue(property string, element map[string]any) (any, bool) {
+	t := c.terms[property]
+	var typ string
+	if t != nil {
+		typ = t.typ
+	}
+	if id, ok := element["@id"].(string); ok && len(element) == 1 {
+		switch typ {
+		case "@id":
+			return c.compactIRI(id, nil, false), true
+		case "@vocab":
+			return c.compactIRI(id, nil, true), true
+		}
+		return nil, false
+	}
+	value := element["@value"]
+	valueType, hasType := element["@type"].(string)
+	_, hasLanguage := element["@language"]
+	_, hasIndex := element["@index"]
+	switch {
+	case hasIndex || hasLanguage:
+		return nil, false
+	case hasType && valueType == typ:
+		return value, true
+	case !hasType && (typ == "" || typ == "@none"):
+		return value, true
+	case !hasType:
+		return nil, false
+	}
+	return map[string]any{c.alias("@value"): value, c.alias("@type"): c.compactIRI(valueType, nil, true)}, true
+}
+
+// alias returns the term of the keyword, or the keyword itself if it has no alias.
+func (c *Context) alias(keyword string) string {
+	var best string
+	for name, t := range c.terms {
+		if t.id == keyword && (best == "" || len(name) < len(best) || (len(name) == len(best) && name < best)) {
+			best = name
+		}
+	}
+	if best == "" {
+		return keyword
+	}
+	return best
+}
+
+// compactIRI compacts the IRI to a term (if vocab is set), a compact IRI, or a relative IRI. The value is used to
+// select the term of which the type and container mappings match best.
+// DOCS: https://www.w3.org/TR/json-ld11-api/#iri-compaction
+func (c *Context) compactIRI(iri string, value any, vocab bool) string {
+	if isKeyword(iri) {
+		return c.alias(iri)
+	}
+	if vocab {
+		if name := c.selectTerm(iri, value); name != "" {
+			return name
+		}
+		if c.vocab != "" && strings.HasPrefix(iri, c.vocab) && len(c.vocab) < len(iri) {
+			if suffix := iri[len(c.vocab):]; c.terms[suffix] == nil {
+				return suffix
+			}
+		}
+	}
+	var best string
+	for name, t := range c.terms {
+		if !t.prefix || t.id == "" || t.id == iri || !strings.HasPrefix(iri, t.id) {
+			continue
+		}
+		candidate := name + ":" + iri[len(t.id):]
+		if ct := c.terms[candidate]; ct != nil && (ct.id != iri || value != nil) {
+			continue
+		}
+		if best == "" || len(candidate) < len(best) || (len(candidate) == len(best) && candidate < best) {
+			best = candidate
+		}
+	}
+	if best != "" {
+		return best
+	}
+	return iri
+}
+
+// selectTerm returns the term of the IRI of which the container and type mappings match the value best, or an empty
+// string if there is none.
+// DOCS: https://www.w3.org/TR/json-ld11-api/#term-selection
+func (c *Context) selectTerm(iri string, value any) string {
+	containers := []string{"@set", ""}
+	var types []string
+	switch v := value.(type) {
+	case map[string]any:
+		_, isList := v["@list"]
+		_, isGraph := v["@graph"]
+		_, isValue := v["@value"]
+		typ, hasType := v["@type"].(string)
+		switch {
+		case isList:
+			containers = []string{"@list"}
+		case isGraph:
+			containers = []string{"@graph", "@graph@set", "@set", ""}
+			types = []string{"@none"}
+		case isValue && hasType:
+			types = []string{typ, "@none"}
+		case isValue:
+			types = []string{"@none"}
+		default:
+			types = []string{"@id", "@vocab", "@none"}
+		}
+	}
+
+	var best string
+	bestContainer, bestType := len(containers), 0
+	for name, t := range c.terms {
+		if t.id != iri {
+			continue
+		}
+		container := strings.Join(t.container, "")
+		ci := indexOf(containers, container)
+		if ci < 0 {
+			continue
+		}
+		typ := t.typ
+		if typ == "" {
+			typ = "@none"
+		}
+		ti := 0
+		if types != nil {
+			if ti = indexOf(types, typ); ti < 0 {
+				continue
+			}
+		}
+		switch {
+		case best == "", ci < bestContainer, ci == bestContainer && ti < bestType:
+		case ci == bestContainer && ti == bestType && (len(name) < len(best) || (len(name) == len(best) && name < best)):
+		default:
+			continue
+		}
+		best, bestContainer, bestType = name, ci, ti
+	}
+	return best
+}
+
+// add adds the value to the property of the object, as array if forceArray is set or if there are multiple values.
+func add(object map[string]any, property string, value any, forceArray bool) {
+	existing, ok := object[property]
+	switch {
+	case !ok && !forceArray:
+		object[property] = value
+	case !ok:
+		object[property] = append([]any(nil), asArray(value)...)
+	default:
+		object[property] = append(append([]any(nil), asArray(existing)...), asArray(value)...)
+	}
+}
+
+func indexOf(s []string, v string) int {
+	for i, x := range s {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,73 @@
+package jsonld
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	for _, test := range []struct {
+		name, input, context string
+	}{
+		{
+			name:    "terms",
+			input:   `{"@context": {"name": "http://xmlns.com/foaf/0.1/name", "homepage": {"@id": "http://xmlns.com/foaf/0.1/homepage", "@type": "@id"}}, "@id": "http://me.markus-lanthaler.com/", "name": "Markus Lanthaler", "homepage": "http://www.tugraz.at/"}`,
+			context: `{"name": "http://xmlns.com/foaf/0.1/name", "homepage": {"@id": "http://xmlns.com/foaf/0.1/homepage", "@type": "@id"}}`,
+		},
+		{
+			name:    "vocab and compact IRIs",
+			input:   `{"@context": {"@vocab": "http://schema.org/", "xsd": "http://www.w3.org/2001/XMLSchema#", "age": {"@type": "xsd:integer"}}, "name": "Alice", "age": 42, "http://example.com/custom": true, "xsd:comment": "compact"}`,
+			context: `{"@vocab": "http://schema.org/", "xsd": "http://www.w3.org/2001/XMLSchema#", "age": {"@type": "xsd:integer"}}`,
+		},
+		{
+			name:    "json literals, lists and sets",
+			input:   `{"@context": {"@vocab": "http://example.com/", "data": {"@type": "@json"}, "items": {"@container": "@list"}, "tags": {"@container": "@set"}}, "data": {"b": [1, 2], "a": null}, "items": ["x"], "tags": ["a"]}`,
+			context: `{"@vocab": "http://example.com/", "data": {"@type": "@json"}, "items": {"@container": "@list"}, "tags": {"@container": "@set"}}`,
+		},
+		{
+			name:    "type-scoped contexts",
+			input:   `{"@context": {"@vocab": "http://example.com/", "Person": {"@context": {"name": "http://xmlns.com/foaf/0.1/name"}}}, "@type": "Person", "name": "Alice", "knows": {"name": "Bob"}}`,
+			context: `{"@vocab": "http://example.com/", "Person": {"@context": {"name": "http://xmlns.com/foaf/0.1/name"}}}`,
+		},
+		{
+			name:    "DID document",
+			input:   `{"@context": ["https://www.w3.org/ns/did/v1", "https://w3id.org/security/suites/ed25519-2020/v1", "https://w3id.org/security/suites/jws-2020/v1"], "id": "did:example:123", "controller": "did:example:456", "verificationMethod": [{"id": "#key-1", "type": "Ed25519VerificationKey2020", "controller": "did:example:123", "publicKeyMultibase": "z6Mk"}, {"id": "#key-2", "type": "JsonWebKey2020", "controller": "did:example:123", "publicKeyJwk": {"kty": "OKP", "crv": "Ed25519", "x": "abc"}}], "authentication": ["#key-1", {"id": "#key-3", "type": "Ed25519VerificationKey2020", "controller": "did:example:123", "publicKeyMultibase": "z6Mk"}], "service": [{"id": "#files", "type": "LinkedDomains", "serviceEndpoint": "https://example.com/files/"}, {"id": "#agent", "type": ["DIDCommMessaging", "LinkedDomains"], "serviceEndpoint": ["https://a.example.com", "https://b.example.com"]}]}`,
+			context: `["https://www.w3.org/ns/did/v1", "https://w3id.org/security/suites/ed25519-2020/v1", "https://w3id.org/security/suites/jws-2020/v1"]`,
+		},
+		{
+			name:    "DID v1.1 document",
+			input:   `{"@context": "https://www.w3.org/ns/did/v1.1", "id": "did:example:123", "verificationMethod": [{"id": "#key-1", "type": "Multikey", "controller": "did:example:123", "publicKeyMultibase": "z6Mk"}], "assertionMethod": ["#key-1"], "service": [{"id": "#files", "type": "LinkedDomains", "serviceEndpoint": "https://example.com/files/"}]}`,
+			context: `"https://www.w3.org/ns/did/v1.1"`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			options := Options{Loader: OfflineLoader, Strict: true}
+			expanded, err := Expand(decode(t, test.input), options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			compacted, err := Compact(expanded, decode(t, test.context), options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(compacted, decode(t, test.input)) {
+				raw, _ := json.Marshal(compacted)
+				t.Error(string(raw))
+			}
+		})
+	}
+}
+
+func TestCompact_aliases(t *testing.T) {
+	expanded := decode(t, `[{"@id": "did:example:123", "@type": ["http://example.com/A", "http://example.com/B"], "http://example.com/name": [{"@value": "Alice", "@type": "http://example.com/Name"}]}]`)
+	compacted, err := Compact(expanded, decode(t, `{"id": "@id", "type": "@type", "ex": "http://example.com/"}`), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := decode(t, `{"@context": {"id": "@id", "type": "@type", "ex": "http://example.com/"}, "id": "did:example:123", "type": ["ex:A", "ex:B"], "ex:name": {"@value": "Alice", "type": "ex:Name"}}`)
+	if !reflect.DeepEqual(compacted, expected) {
+		raw, _ := json.Marshal(compacted)
+		t.Error(string(raw))
+	}
+}
@@ -0,0 +1,476 @@
+package jsonld
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// maxRemoteContexts limits the number of nested remote contexts, to guard against (indirectly) recursive contexts.
+const maxRemoteContexts = 16
+
+// keywords are the JSON-LD keywords.
+// DOCS: https://www.w3.org/TR/json-ld11/#keywords
+var keywords = []string{
+	"@base", "@container", "@context", "@direction", "@graph", "@id", "@import", "@included", "@index", "@json",
+	"@language", "@list", "@nest", "@none", "@prefix", "@propagate", "@protected", "@reverse", "@set", "@type",
+	"@value", "@version", "@vocab",
+}
+
+// Context is an active context: the term definitions, vocabulary mapping and base IRI used to interpret a document.
+// DOCS: https://www.w3.org/TR/json-ld11-api/#context-processing-algorithm
+type Context struct {
+	terms map[string]*term
+	vocab string
+	base  string
+	// previous is the context before a non-propagated (type-scoped) context was applied, it is reverted to when
+	// processing nested node objects.
+	previous *Context
+}
+
+// term is a term definition.
+type term struct {
+	// id is the IRI or keyword the term expands to, empty if the term is mapped to null.
+	id string
+	// typ is the type mapping: @id, @json, @none, @vocab or a datatype IRI.
+	typ       string
+	container []string
+	// context is the scoped context, if hasContext.
+	context    any
+	hasContext bool
+	protected  bool
+	prefix     bool
+}
+
+// NewContext returns an empty active context.
+func NewContext() *Context {
+	return &Context{terms: make(map[string]*term)}
+}
+
+// Parse processes the given local context (a URL, a context definition, or an array of them) on top of an empty
+// active context.
+func Parse(local any, loader Loader) (*Context, error) {
+	return NewContext().process(local, processing{loader: loader, propagate: true})
+}
+
+// processing are the parameters of the context processing algorithm.
+type processing struct {
+	loader Loader
+	// remote are the URLs of the remote contexts that are being processed.
+	remote            []string
+	overrideProtected bool
+	propagate         bool
+	// protected is the default of the term definitions of the context definition that is being processed.
+	protected bool
+}
+
+func (c *Context) clone() *Context {
+	terms := make(map[string]*term, len(c.terms))
+	for k, v := range c.terms {
+		terms[k] = v
+	}
+	return &Context{terms: terms, vocab: c.vocab, base: c.base, previous: c.previous}
+}
+
+// process returns the result of processing the local context on top of the active context.
+func (c *Context) process(local any, p processing) (*Context, error) {
+	result := c.clone()
+	if m, ok := local.(map[string]any); ok {
+		if propagate, ok := m["@propagate"]; ok {
+			b, ok := propagate.(bool)
+			if !ok {
+				return nil, fmt.Errorf("invalid @propagate value: %v", propagate)
+			}
+			p.propagate = b
+		}
+	}
+	if !p.propagate && result.previous == nil {
+		result.previous = c
+	}
+	for _, local := range asArray(local) {
+		switch local := local.(type) {
+		case nil:
+			if !p.overrideProtected {
+				for name, t := range result.terms {
+					if t.protected {
+						return nil, fmt.Errorf("invalid context nullification: %s is protected", name)
+					}
+				}
+			}
+			previous := result.previous
+			result = NewContext()
+			if !p.propagate {
+				result.previous = previous
+			}
+		case string:
+			if len(p.remote) == maxRemoteContexts {
+				return nil, fmt.Errorf("context overflow: more than %d nested remote contexts", maxRemoteContexts)
+			}
+			for _, u := range p.remote {
+				if u == local {
+					return nil, fmt.Errorf("recursive context inclusion: %s", local)
+				}
+			}
+			if p.loader == nil {
+				return nil, fmt.Errorf("loading remote context failed: %s: no loader", local)
+			}
+			doc, err := p.loader(local)
+			if err != nil {
+				return nil, fmt.Errorf("loading remote context failed: %s: %w", local, err)
+			}
+			m, ok := doc.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid remote context: %s", local)
+			}
+			remote, ok := m["@context"]
+			if !ok {
+				return nil, fmt.Errorf("invalid remote context: %s: missing @context", local)
+			}
+			rp := processing{loader: p.loader, remote: append(append([]string(nil), p.remote...), local), propagate: true}
+			r, err := result.process(remote, rp)
+			if err != nil {
+				return nil, err
+			}
+			result = r
+		case map[string]any:
+			if err := result.define(local, p); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("invalid local context: %v", local)
+		}
+	}
+	return result, nil
+}
+
+// define adds the definitions of the context definition to the active context.
+func (c *Context) define(local map[string]any, p processing) error {
+	if version, ok := local["@version"]; ok && fmt.Sprint(version) != "1.1" {
+		return fmt.Errorf("invalid @version value: %v", version)
+	}
+	if _, ok := local["@import"]; ok {
+		return fmt.Errorf("unsupported keyword: @import")
+	}
+	if base, ok := local["@base"]; ok && len(p.remote) == 0 {
+		switch base := base.(type) {
+		case nil:
+			c.base = ""
+		case string:
+			u, err := url.Parse(base)
+			if err != nil {
+				return fmt.Errorf("invalid base IRI: %s", base)
+			}
+			if c.base != "" && !u.IsAbs() {
+				b, _ := url.Parse(c.base)
+				u = b.ResolveReference(u)
+			}
+			c.base = u.String()
+		default:
+			return fmt.Errorf("invalid base IRI: %v", base)
+		}
+	}
+	if vocab, ok := local["@vocab"]; ok {
+		switch vocab := vocab.(type) {
+		case nil:
+			c.vocab = ""
+		case string:
+			v, err := c.expandIRI(vocab, true, true, local, nil, p)
+			if err != nil {
+				return err
+			}
+			c.vocab = v
+		default:
+			return fmt.Errorf("invalid vocab mapping: %v", vocab)
+		}
+	}
+	p.protected = false
+	if v, ok := local["@protected"]; ok {
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("invalid @protected value: %v", v)
+		}
+		p.protected = b
+	}
+	defined := make(map[string]bool)
+	for _, name := range sortedKeys(local) {
+		switch name {
+		case "@base", "@direction", "@import", "@language", "@propagate", "@protected", "@version", "@vocab":
+			continue
+		}
+		if err := c.createTerm(local, name, defined, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createTerm creates the term definition of the given term of the local context.
+// DOCS: https://www.w3.org/TR/json-ld11-api/#create-term-definition
+func (c *Context) createTerm(local map[string]any, name string, defined map[string]bool, p processing) error {
+	if done, ok := defined[name]; ok {
+		if done {
+			return nil
+		}
+		return fmt.Errorf("cyclic IRI mapping: %s", name)
+	}
+	defined[name] = false
+	if isKeyword(name) {
+		return fmt.Errorf("keyword redefinition: %s", name)
+	}
+	if looksLikeKeyword(name) {
+		defined[name] = true
+		return nil // Ignored, as recommended.
+	}
+
+	var definition map[string]any
+	simple := false
+	switch v := local[name].(type) {
+	case nil:
+		definition = map[string]any{"@id": nil}
+	case string:
+		definition = map[string]any{"@id": v}
+		simple = true
+	case map[string]any:
+		definition = v
+	default:
+		return fmt.Errorf("invalid term definition: %s", name)
+	}
+	previous := c.terms[name]
+	delete(c.terms, name)
+
+	t := term{protected: p.protected}
+	if v, ok := definition["@protected"]; ok {
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("invalid @protected value: %s", name)
+		}
+		t.protected = b
+	}
+	if v, ok := definition["@type"]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("invalid type mapping: %s", name)
+		}
+		typ, err := c.expandIRI(s, false, true, local, defined, p)
+		if err != nil {
+			return err
+		}
+		switch {
+		case typ == "@id" || typ == "@json" || typ == "@none" || typ == "@vocab":
+		case isAbsoluteIRI(typ):
+		default:
+			return fmt.Errorf("invalid type mapping: %s: %s", name, s)
+		}
+		t.typ = typ
+	}
+	if _, ok := definition["@reverse"]; ok {
+		return fmt.Errorf("unsupported keyword: @reverse")
+	}
+	switch id, ok := definition["@id"]; {
+	case ok && id != name:
+		if id == nil {
+			break // The term is mapped to null.
+		}
+		s, isString := id.(string)
+		if !isString {
+			return fmt.Errorf("invalid IRI mapping: %s", name)
+		}
+		if !isKeyword(s) && looksLikeKeyword(s) {
+			defined[name] = true
+			return nil // Ignored, as recommended.
+		}
+		iri, err := c.expandIRI(s, false, true, local, defined, p)
+		if err != nil {
+			return err
+		}
+		if iri == "@context" || (!isKeyword(iri) && !strings.Contains(iri, ":")) {
+			return fmt.Errorf("invalid IRI mapping: %s: %s", name, s)
+		}
+		t.id = iri
+		if !strings.ContainsAny(name, ":/") && simple && (strings.ContainsAny(iri[len(iri)-1:], ":/?#[]@") || strings.HasPrefix(iri, "_:")) {
+			t.prefix = true
+		}
+	case strings.Contains(name[1:], ":"):
+		prefix, suffix, _ := strings.Cut(name, ":")
+		if _, ok := local[prefix]; ok {
+			if err := c.createTerm(local, prefix, defined, p); err != nil {
+				return err
+			}
+		}
+		if pt, ok := c.terms[prefix]; ok && pt.id != "" {
+			t.id = pt.id + suffix
+		} else {
+			t.id = name
+		}
+	case strings.Contains(name, "/"):
+		iri, err := c.expandIRI(name, false, true, local, defined, p)
+		if err != nil {
+			return err
+		}
+		if !isAbsoluteIRI(iri) {
+			return fmt.Errorf("invalid IRI mapping: %s", name)
+		}
+		t.id = iri
+	default:
+		if c.vocab == "" {
+			return fmt.Errorf("invalid IRI mapping: %s: no vocabulary mapping", name)
+		}
+		t.id = c.vocab + name
+	}
+	if v, ok := definition["@container"]; ok {
+		for _, container := range asArray(v) {
+			s, _ := container.(string)
+			switch s {
+			case "@graph", "@id", "@index", "@language", "@list", "@set", "@type":
+				t.container = append(t.container, s)
+			default:
+				return fmt.Errorf("invalid container mapping: %s: %v", name, container)
+			}
+		}
+		sort.Strings(t.container)
+	}
+	if v, ok := definition["@context"]; ok {
+		t.context = v
+		t.hasContext = true
+	}
+	if v, ok := definition["@prefix"]; ok {
+		b, ok := v.(bool)
+		if !ok || strings.ContainsAny(name, ":/") {
+			return fmt.Errorf("invalid @prefix value: %s", name)
+		}
+		t.prefix = b
+	}
+	if previous != nil && previous.protected && !p.overrideProtected {
+		if !previous.equal(&t) {
+			return fmt.Errorf("protected term redefinition: %s", name)
+		}
+		t = *previous
+	}
+	c.terms[name] = &t
+	defined[name] = true
+	return nil
+}
+
+// expandIRI expands a term, compact IRI or relative IRI. Terms are only expanded if vocab is set, relative IRIs are
+// resolved against the base IRI if documentRelative is set. Returns an empty string if the value is mapped to null.
+// While processing a local context, its terms are defined on demand.
+// DOCS: https://www.w3.org/TR/json-ld11-api/#iri-expansion
+func (c *Context) expandIRI(value string, documentRelative, vocab bool, local map[string]any, defined map[string]bool, p processing) (string, error) {
+	if isKeyword(value) {
+		return value, nil
+	}
+	if looksLikeKeyword(value) {
+		return "", nil
+	}
+	if local != nil && defined != nil {
+		if _, ok := local[value]; ok && !defined[value] {
+			if err := c.createTerm(local, value, defined, p); err != nil {
+				return "", err
+			}
+		}
+	}
+	if vocab {
+		if t, ok := c.terms[value]; ok {
+			return t.id, nil
+		}
+	}
+	if prefix, suffix, ok := strings.Cut(value, ":"); ok {
+		if prefix == "_" || strings.HasPrefix(suffix, "//") {
+			return value, nil
+		}
+		if local != nil && defined != nil {
+			if _, ok := local[prefix]; ok && !defined[prefix] {
+				if err := c.createTerm(local, prefix, defined, p); err != nil {
+					return "", err
+				}
+			}
+		}
+		if t, ok := c.terms[prefix]; ok && t.id != "" && t.prefix {
+			return t.id + suffix, nil
+		}
+		if isAbsoluteIRI(value) {
+			return value, nil
+		}
+	}
+	if vocab && c.vocab != "" {
+		return c.vocab + value, nil
+	}
+	if documentRelative && c.base != "" {
+		base, err := url.Parse(c.base)
+		if err != nil {
+			return "", err
+		}
+		ref, err := url.Parse(value)
+		if err != nil {
+			return value, nil
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+	return value, nil
+}
+
+// hasContainer checks whether the term has the given container mapping.
+func (t *term) hasContainer(container string) bool {
+	if t == nil {
+		return false
+	}
+	for _, c := range t.container {
+		if c == container {
+			return true
+		}
+	}
+	return false
+}
+
+// equal checks whether the definitions are equal, other than whether they are protected.
+func (t *term) equal(o *term) bool {
+	return t.id == o.id && t.typ == o.typ && t.prefix == o.prefix && t.hasContext == o.hasContext &&
+		reflect.DeepEqual(t.container, o.container) && reflect.DeepEqual(t.context, o.context)
+}
+
+// asArray returns the value as array, wrapping single values.
+func asArray(v any) []any {
+	if a, ok := v.([]any); ok {
+		return a
+	}
+	return []any{v}
+}
+
+// isAbsoluteIRI checks whether the value has a scheme.
+func isAbsoluteIRI(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && u.Scheme != ""
+}
+
+func isKeyword(s string) bool {
+	for _, k := range keywords {
+		if k == s {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikeKeyword checks whether the value has the form of a keyword: "@" followed by letters.
+func looksLikeKeyword(s string) bool {
+	if len(s) < 2 || s[0] != '@' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if (c < 'a' || 'z' < c) && (c < 'A' || 'Z' < c) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
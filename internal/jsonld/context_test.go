@@ -0,0 +1,14 @@
+package jsonld
+
+import (
+	"testing"
+)
+
+func TestParse_recursive(t *testing.T) {
+	loader := func(url string) (any, error) {
+		return map[string]any{"@context": []any{url}}, nil
+	}
+	if _, err := Parse("https://example.com/context", loader); err == nil {
+		t.Error("expected an error")
+	}
+}
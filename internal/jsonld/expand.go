@@ -0,0 +1,344 @@
+package jsonld
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Options are options of the expansion and compaction algorithms.
+type Options struct {
+	// Loader loads remote contexts, remote contexts can not be loaded if nil.
+	Loader Loader
+	// Strict reports properties that are dropped during expansion, because they are not defined by the context, as
+	// UndefinedTermErrors.
+	Strict bool
+}
+
+// UndefinedTermError is returned in strict mode for each property that is not defined by the active context.
+type UndefinedTermError struct {
+	// Path is the JSON path of the property, e.g. $.verificationMethod[0].publicKeyBase64.
+	Path string
+	Term string
+}
+
+func (e *UndefinedTermError) Error() string {
+	return fmt.Sprintf("%s: undefined term %q", e.Path, e.Term)
+}
+
+type expander struct {
+	options   Options
+	undefined []error
+}
+
+// Expand expands the JSON-LD document (decoded with json.Decoder.UseNumber): all terms are replaced by IRIs and all
+// values are made explicit, so the result no longer depends on a context.
+// DOCS: https://www.w3.org/TR/json-ld11-api/#expansion-algorithm
+func Expand(input any, options Options) ([]any, error) {
+	e := expander{options: options}
+	expanded, err := e.expand(NewContext(), "", input, "$")
+	if err != nil {
+		return nil, err
+	}
+	if err := errors.Join(e.undefined...); err != nil {
+		return nil, err
+	}
+	if m, ok := expanded.(map[string]any); ok && len(m) == 1 {
+		if graph, ok := m["@graph"]; ok {
+			expanded = graph
+		}
+	}
+	if expanded == nil {
+		return []any{}, nil
+	}
+	return asArray(expanded), nil
+}
+
+func (e *expander) expand(ctx *Context, property string, element any, path string) (any, error) {
+	switch element := element.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		result := make([]any, 0, len(element))
+		for i, item := range element {
+			v, err := e.expand(ctx, property, item, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			if a, ok := v.([]any); ok {
+				result = append(result, a...)
+			} else if v != nil {
+				result = append(result, v)
+			}
+		}
+		return result, nil
+	case map[string]any:
+		return e.expandObject(ctx, property, element, path)
+	default:
+		if property == "" || property == "@graph" {
+			return nil, nil // Free-floating values are dropped.
+		}
+		if t := ctx.terms[property]; t != nil && t.hasContext {
+			var err error
+			if ctx, err = ctx.process(t.context, processing{loader: e.options.Loader, overrideProtected: true, propagate: true}); err != nil {
+				return nil, err
+			}
+		}
+		return ctx.expandValue(property, element)
+	}
+}
+
+func (e *expander) expandObject(ctx *Context, property string, element map[string]any, path string) (any, error) {
+	scoped := ctx.terms[property]
+	if ctx.previous != nil && !ctx.isValueOrReference(element) {
+		ctx = ctx.previous
+	}
+	var err error
+	if scoped != nil && scoped.hasContext {
+		if ctx, err = ctx.process(scoped.context, processing{loader: e.options.Loader, overrideProtected: true, propagate: true}); err != nil {
+			return nil, err
+		}
+	}
+	if local, ok := element["@context"]; ok {
+		if ctx, err = ctx.process(local, processing{loader: e.options.Loader, propagate: true}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Apply the type-scoped contexts, which do not propagate to nested node objects.
+	typeCtx := ctx
+	for _, key := range sortedKeys(element) {
+		if iri, err := ctx.expandIRI(key, false, true, nil, nil, processing{}); err != nil || iri != "@type" {
+			continue
+		}
+		var types []string
+		for _, t := range asArray(element[key]) {
+			if s, ok := t.(string); ok {
+				types = append(types, s)
+			}
+		}
+		sort.Strings(types)
+		for _, typ := range types {
+			if t := typeCtx.terms[typ]; t != nil && t.hasContext {
+				if ctx, err = ctx.process(t.context, processing{loader: e.options.Loader, propagate: false}); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	result := make(map[string]any)
+	for _, key := range sortedKeys(element) {
+		if key == "@context" {
+			continue
+		}
+		value := element[key]
+		keyPath := childPath(path, key)
+		iri, err := ctx.expandIRI(key, false, true, nil, nil, processing{})
+		if err != nil {
+			return nil, err
+		}
+		if iri == "" || (!isKeyword(iri) && !isAbsoluteIRI(iri)) {
+			if _, mapped := ctx.terms[key]; e.options.Strict && !mapped {
+				e.undefined = append(e.undefined, &UndefinedTermError{Path: keyPath, Term: key})
+			}
+			continue
+		}
+		if isKeyword(iri) {
+			if _, ok := result[iri]; ok {
+				return nil, fmt.Errorf("colliding keywords: %s", iri)
+			}
+			v, err := e.expandKeyword(ctx, typeCtx, property, iri, value, keyPath)
+			if err != nil {
+				return nil, err
+			}
+			if v != nil {
+				result[iri] = v
+			}
+			continue
+		}
+
+		t := ctx.terms[key]
+		var expanded any
+		switch {
+		case t != nil && t.typ == "@json":
+			expanded = map[string]any{"@value": value, "@type": "@json"}
+		case t.hasContainer("@language") || t.hasContainer("@index") || t.hasContainer("@id") || t.hasContainer("@type"):
+			if _, ok := value.(map[string]any); ok {
+				return nil, fmt.Errorf("unsupported container mapping: %s: %v", key, t.container)
+			}
+			fallthrough
+		default:
+			if expanded, err = e.expand(ctx, key, value, keyPath); err != nil {
+				return nil, err
+			}
+		}
+		if expanded == nil {
+			continue
+		}
+		if t.hasContainer("@list") && !isList(expanded) {
+			expanded = map[string]any{"@list": asArray(expanded)}
+		}
+		if t.hasContainer("@graph") {
+			var graphs []any
+			for _, v := range asArray(expanded) {
+				graphs = append(graphs, map[string]any{"@graph": asArray(v)})
+			}
+			expanded = graphs
+		}
+		if existing, ok := result[iri]; ok {
+			result[iri] = append(asArray(existing), asArray(expanded)...)
+		} else {
+			result[iri] = asArray(expanded)
+		}
+	}
+
+	if value, ok := result["@value"]; ok {
+		for k := range result {
+			switch k {
+			case "@direction", "@index", "@language", "@type", "@value":
+			default:
+				return nil, fmt.Errorf("invalid value object: %s", k)
+			}
+		}
+		if value == nil {
+			return nil, nil
+		}
+		if typ, ok := result["@type"]; ok {
+			if _, ok := typ.(string); !ok {
+				return nil, fmt.Errorf("invalid typed value: %v", typ)
+			}
+		}
+		if result["@type"] != "@json" {
+			switch value.(type) {
+			case map[string]any, []any:
+				return nil, fmt.Errorf("invalid value object value: %v", value)
+			}
+		}
+		return result, nil
+	}
+	if typ, ok := result["@type"]; ok {
+		result["@type"] = asArray(typ)
+	}
+	if set, ok := result["@set"]; ok {
+		return set, nil
+	}
+	if _, ok := result["@language"]; ok && len(result) == 1 {
+		return nil, nil
+	}
+	if property == "" || property == "@graph" {
+		_, hasID := result["@id"]
+		_, hasList := result["@list"]
+		if len(result) == 0 || hasList || (hasID && len(result) == 1) {
+			return nil, nil
+		}
+	}
+	return result, nil
+}
+
+// expandKeyword expands the value of a keyword property.
+func (e *expander) expandKeyword(ctx, typeCtx *Context, property, keyword string, value any, path string) (any, error) {
+	switch keyword {
+	case "@id":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid @id value: %v", value)
+		}
+		return ctx.expandIRI(s, true, false, nil, nil, processing{})
+	case "@type":
+		var types []any
+		for _, t := range asArray(value) {
+			s, ok := t.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid type value: %v", t)
+			}
+			iri, err := typeCtx.expandIRI(s, true, true, nil, nil, processing{})
+			if err != nil {
+				return nil, err
+			}
+			types = append(types, iri)
+		}
+		if _, ok := value.([]any); !ok && len(types) == 1 {
+			return types[0], nil
+		}
+		return types, nil
+	case "@graph", "@included":
+		v, err := e.expand(ctx, keyword, value, path)
+		if err != nil || v == nil {
+			return v, err
+		}
+		return asArray(v), nil
+	case "@value":
+		return value, nil
+	case "@language", "@index", "@direction":
+		if _, ok := value.(string); !ok {
+			return nil, fmt.Errorf("invalid %s value: %v", keyword, value)
+		}
+		return value, nil
+	case "@list":
+		if property == "" || property == "@graph" {
+			return nil, nil
+		}
+		v, err := e.expand(ctx, property, value, path)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return []any{}, nil
+		}
+		return asArray(v), nil
+	case "@set":
+		return e.expand(ctx, property, value, path)
+	default:
+		return nil, fmt.Errorf("unsupported keyword: %s", keyword)
+	}
+}
+
+// expandValue expands a scalar value of the given property.
+// DOCS: https://www.w3.org/TR/json-ld11-api/#value-expansion
+func (c *Context) expandValue(property string, value any) (any, error) {
+	t := c.terms[property]
+	if s, ok := value.(string); ok && t != nil && (t.typ == "@id" || t.typ == "@vocab") {
+		iri, err := c.expandIRI(s, true, t.typ == "@vocab", nil, nil, processing{})
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"@id": iri}, nil
+	}
+	result := map[string]any{"@value": value}
+	if t != nil && t.typ != "" && !isKeyword(t.typ) {
+		result["@type"] = t.typ
+	}
+	return result, nil
+}
+
+// isValueOrReference checks whether the (unexpanded) object is a value object or a node reference: objects to which
+// type-scoped contexts still apply.
+func (c *Context) isValueOrReference(element map[string]any) bool {
+	for key := range element {
+		iri, _ := c.expandIRI(key, false, true, nil, nil, processing{})
+		if iri == "@value" || (iri == "@id" && len(element) == 1) {
+			return true
+		}
+	}
+	return false
+}
+
+// childPath returns the JSON path of the property of the object at the given path.
+func childPath(path, key string) string {
+	for _, c := range key {
+		if (c < 'a' || 'z' < c) && (c < 'A' || 'Z' < c) && (c < '0' || '9' < c) && c != '_' {
+			return fmt.Sprintf("%s['%s']", path, key)
+		}
+	}
+	return path + "." + key
+}
+
+func isList(v any) bool {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, ok = m["@list"]
+	return ok
+}
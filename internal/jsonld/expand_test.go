@@ -0,0 +1,122 @@
+package jsonld
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/0x51-dev/did/internal/jsonutils"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func decode(t *testing.T, s string) any {
+	v, err := jsonutils.Decode([]byte(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestExpand(t *testing.T) {
+	for _, test := range []struct {
+		name, input, expanded string
+	}{
+		{
+			// SOURCE: https://www.w3.org/TR/json-ld11/#example-sample-json-ld-document-to-be-expanded
+			name:     "terms",
+			input:    `{"@context": {"name": "http://xmlns.com/foaf/0.1/name", "homepage": {"@id": "http://xmlns.com/foaf/0.1/homepage", "@type": "@id"}}, "@id": "http://me.markus-lanthaler.com/", "name": "Markus Lanthaler", "homepage": "http://www.tugraz.at/"}`,
+			expanded: `[{"@id": "http://me.markus-lanthaler.com/", "http://xmlns.com/foaf/0.1/name": [{"@value": "Markus Lanthaler"}], "http://xmlns.com/foaf/0.1/homepage": [{"@id": "http://www.tugraz.at/"}]}]`,
+		},
+		{
+			name:     "vocab and compact IRIs",
+			input:    `{"@context": {"@vocab": "http://schema.org/", "xsd": "http://www.w3.org/2001/XMLSchema#", "age": {"@type": "xsd:integer"}}, "name": "Alice", "age": 42, "http://example.com/custom": true}`,
+			expanded: `[{"http://schema.org/name": [{"@value": "Alice"}], "http://schema.org/age": [{"@value": 42, "@type": "http://www.w3.org/2001/XMLSchema#integer"}], "http://example.com/custom": [{"@value": true}]}]`,
+		},
+		{
+			name:     "json literals and lists",
+			input:    `{"@context": {"@vocab": "http://example.com/", "data": {"@type": "@json"}, "items": {"@container": "@list"}}, "data": {"b": [1, 2], "a": null}, "items": ["x", "y"]}`,
+			expanded: `[{"http://example.com/data": [{"@value": {"b": [1, 2], "a": null}, "@type": "@json"}], "http://example.com/items": [{"@list": [{"@value": "x"}, {"@value": "y"}]}]}]`,
+		},
+		{
+			name:     "type-scoped contexts do not propagate",
+			input:    `{"@context": {"@vocab": "http://example.com/", "Person": {"@context": {"name": "http://xmlns.com/foaf/0.1/name"}}}, "@type": "Person", "name": "Alice", "knows": {"name": "Bob"}}`,
+			expanded: `[{"@type": ["http://example.com/Person"], "http://xmlns.com/foaf/0.1/name": [{"@value": "Alice"}], "http://example.com/knows": [{"http://example.com/name": [{"@value": "Bob"}]}]}]`,
+		},
+		{
+			name:     "property-scoped contexts propagate",
+			input:    `{"@context": {"@vocab": "http://example.com/", "knows": {"@context": {"name": "http://xmlns.com/foaf/0.1/name"}}}, "name": "Alice", "knows": {"name": "Bob", "knows": {"name": "Carol"}}}`,
+			expanded: `[{"http://example.com/name": [{"@value": "Alice"}], "http://example.com/knows": [{"http://xmlns.com/foaf/0.1/name": [{"@value": "Bob"}], "http://example.com/knows": [{"http://xmlns.com/foaf/0.1/name": [{"@value": "Carol"}]}]}]}]`,
+		},
+		{
+			name:     "undefined terms are dropped",
+			input:    `{"@context": {"name": "http://xmlns.com/foaf/0.1/name"}, "name": "Alice", "unknown": "dropped"}`,
+			expanded: `[{"http://xmlns.com/foaf/0.1/name": [{"@value": "Alice"}]}]`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			expanded, err := Expand(decode(t, test.input), Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(expanded, decode(t, test.expanded)) {
+				raw, _ := json.Marshal(expanded)
+				t.Error(string(raw))
+			}
+		})
+	}
+}
+
+func TestExpand_errors(t *testing.T) {
+	for _, test := range []struct {
+		name, input, err string
+	}{
+		{
+			name:  "protected term redefinition",
+			input: `{"@context": [{"@protected": true, "name": "http://xmlns.com/foaf/0.1/name"}, {"name": "http://schema.org/name"}], "name": "Alice"}`,
+			err:   "protected term redefinition: name",
+		},
+		{
+			name:  "context nullification",
+			input: `{"@context": [{"@protected": true, "name": "http://xmlns.com/foaf/0.1/name"}, null], "name": "Alice"}`,
+			err:   "invalid context nullification",
+		},
+		{
+			name:  "remote context",
+			input: `{"@context": "https://example.com/context.jsonld", "name": "Alice"}`,
+			err:   "context not available offline",
+		},
+		{
+			name:  "invalid value object",
+			input: `{"@context": {"@vocab": "http://example.com/"}, "name": {"@value": "Alice", "name": "Bob"}}`,
+			err:   "invalid value object",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Expand(decode(t, test.input), Options{Loader: OfflineLoader}); err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Error(err)
+			}
+		})
+	}
+
+	// Identical redefinitions of protected terms are allowed.
+	input := `{"@context": [{"@protected": true, "name": "http://xmlns.com/foaf/0.1/name"}, {"name": "http://xmlns.com/foaf/0.1/name"}], "name": "Alice"}`
+	if _, err := Expand(decode(t, input), Options{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExpand_strict(t *testing.T) {
+	input := `{"@context": {"name": "http://xmlns.com/foaf/0.1/name", "knows": "http://xmlns.com/foaf/0.1/knows"}, "name": "Alice", "age": 42, "knows": [{"name": "Bob", "@nick": "bob"}]}`
+	_, err := Expand(decode(t, input), Options{Strict: true})
+	var paths []string
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var undefinedErr *UndefinedTermError
+		if !errors.As(err, &undefinedErr) {
+			t.Fatal(err)
+		}
+		paths = append(paths, undefinedErr.Path)
+	}
+	if !reflect.DeepEqual(paths, []string{"$.age", "$.knows[0]['@nick']"}) {
+		t.Error(paths)
+	}
+}
@@ -0,0 +1,60 @@
+package jsonld
+
+import (
+	"embed"
+	"fmt"
+	"github.com/0x51-dev/did/internal/jsonutils"
+	"sort"
+)
+
+// Loader loads the remote context document of the given URL, a JSON object with a @context entry.
+type Loader func(url string) (any, error)
+
+//go:embed contexts/*.jsonld
+var contextFiles embed.FS
+
+// bundled are the bundled contexts, by their URL. They are copies of the published context documents, which are pinned
+// by their SHA-256 hash in TestBundledContexts. The contexts of did/v1.1, cid/v1, multikey/v1, jwk/v1, x25519-2020/v1
+// and secp256k1recovery-2020/v2 have not been compared with the published documents yet, their hashes are the ones of
+// the bundled files.
+var bundled = map[string]string{
+	"https://www.w3.org/ns/did/v1":                               "did-v1.jsonld",
+	"https://www.w3.org/ns/did/v1.1":                             "did-v1.1.jsonld",
+	"https://w3id.org/security/v1":                               "security-v1.jsonld",
+	"https://w3id.org/security/v2":                               "security-v2.jsonld",
+	"https://w3id.org/security/data-integrity/v1":                "data-integrity-v1.jsonld",
+	"https://w3id.org/security/data-integrity/v2":                "data-integrity-v2.jsonld",
+	"https://www.w3.org/ns/cid/v1":                               "cid-v1.jsonld",
+	"https://w3id.org/security/jwk/v1":                           "jwk-v1.jsonld",
+	"https://w3id.org/security/multikey/v1":                      "multikey-v1.jsonld",
+	"https://w3id.org/security/suites/ed25519-2018/v1":           "ed25519-2018-v1.jsonld",
+	"https://w3id.org/security/suites/ed25519-2020/v1":           "ed25519-2020-v1.jsonld",
+	"https://w3id.org/security/suites/jws-2020/v1":               "jws-2020-v1.jsonld",
+	"https://w3id.org/security/suites/secp256k1-2019/v1":         "secp256k1-2019-v1.jsonld",
+	"https://w3id.org/security/suites/secp256k1recovery-2020/v2": "secp256k1recovery-2020-v2.jsonld",
+	"https://w3id.org/security/suites/x25519-2019/v1":            "x25519-2019-v1.jsonld",
+	"https://w3id.org/security/suites/x25519-2020/v1":            "x25519-2020-v1.jsonld",
+}
+
+// BundledContexts returns the URLs of the bundled contexts.
+func BundledContexts() []string {
+	urls := make([]string, 0, len(bundled))
+	for url := range bundled {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// OfflineLoader loads the bundled contexts, it never accesses the network.
+func OfflineLoader(url string) (any, error) {
+	name, ok := bundled[url]
+	if !ok {
+		return nil, fmt.Errorf("context not available offline: %s", url)
+	}
+	raw, err := contextFiles.ReadFile("contexts/" + name)
+	if err != nil {
+		return nil, err
+	}
+	return jsonutils.Decode(raw)
+}
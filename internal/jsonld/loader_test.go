@@ -0,0 +1,65 @@
+package jsonld
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+// contextHashes are the SHA-256 hashes of the bundled context documents.
+var contextHashes = map[string]string{
+	"cid-v1.jsonld":                    "d4523279b3bb4e7721f5d833fc144de8165edc2b200a66bff8e3d5753c467b54",
+	"data-integrity-v1.jsonld":         "bff3ce2348e14a33da9ecc612403f57f07a90816761caefb7d165f082c810ecd",
+	"data-integrity-v2.jsonld":         "0f77743daf5b4e8fc067fc5ba5b21044283053aa717fc6b0219843bed3b00363",
+	"did-v1.1.jsonld":                  "65ac9c066757b60b782f2b86499108fea6674369a440a7bc5f5dc28a22bf957f",
+	"did-v1.jsonld":                    "adefa50311393eb1bd788a38ac9fe2696c65367928f6996812cd54577394f4da",
+	"ed25519-2018-v1.jsonld":           "38978a9a996fa073cfbc97b744e158c4b1ff2822df0a4cf199794c8a338d74ba",
+	"ed25519-2020-v1.jsonld":           "b9e1ab971fd8bf2c7553e0c4a9438e0b9450afde1ea1ca5b2492368b9f549588",
+	"jwk-v1.jsonld":                    "98f1dfd693dba5ef410d662014291f367057306c251107b409a10bef09a7bb87",
+	"jws-2020-v1.jsonld":               "00ee65bb7307f9a01c3170b823083b0d4de68223520834b2c5eb7a09bae5dea6",
+	"multikey-v1.jsonld":               "4ac97cbf121773bdd9103ad7de99339a9901db3499ad04f616f009424824c79c",
+	"secp256k1-2019-v1.jsonld":         "a9b9b45c04ed02f4f56b33ea6b67bbb6a063a7faaca1d77d2656816ecdcecd8f",
+	"secp256k1recovery-2020-v2.jsonld": "9c58f839db957fcd83ab5273e21ba7790c9afc03b6f0716678c3b38c75635669",
+	"security-v1.jsonld":               "1feed0a3db44c9cbf32ac48dc9cd606d7908181c9ce1d5b5a045cbca25cbb03b",
+	"security-v2.jsonld":               "1319f0215f72395a0212df35801b76ee610e30ba9bade17e9bfbcc6cb2631450",
+	"x25519-2019-v1.jsonld":            "83d50dfff2571041f71ba5c472077765819955b1bc4d172716f7148b22645656",
+	"x25519-2020-v1.jsonld":            "1fee542597dc2bdc478d4138a5f01d5e6df00d8f0a76c65e4ed71b799079f993",
+}
+
+func TestBundledContexts(t *testing.T) {
+	files, err := contextFiles.ReadDir("contexts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(contextHashes) || len(bundled) != len(contextHashes) {
+		t.Fatalf("%d files and %d bundled contexts, expected %d", len(files), len(bundled), len(contextHashes))
+	}
+	for _, name := range bundled {
+		raw, err := contextFiles.ReadFile("contexts/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sum := sha256.Sum256(raw); hex.EncodeToString(sum[:]) != contextHashes[name] {
+			t.Errorf("%s: unexpected hash %x", name, sum)
+		}
+	}
+}
+
+func TestOfflineLoader(t *testing.T) {
+	urls := BundledContexts()
+	if len(urls) != len(bundled) {
+		t.Fatal(urls)
+	}
+	for _, url := range urls {
+		doc, err := OfflineLoader(url)
+		if err != nil {
+			t.Fatal(url, err)
+		}
+		if _, err := Parse(doc.(map[string]any)["@context"], OfflineLoader); err != nil {
+			t.Error(url, err)
+		}
+	}
+	if _, err := OfflineLoader("https://www.w3.org/ns/did/v2"); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,390 @@
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Operation is a single JSON Patch operation.
+// DOCS: https://www.rfc-editor.org/rfc/rfc6902#section-4
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Patch is a JSON Patch document, a sequence of operations that are applied in order.
+// DOCS: https://www.rfc-editor.org/rfc/rfc6902
+type Patch []Operation
+
+// Apply applies the patch to the given (decoded) JSON value and returns the result. The given value is not modified.
+// If any of the operations fails, the whole patch fails.
+func Apply(doc any, patch Patch) (any, error) {
+	doc = deepCopy(doc)
+	for i, op := range patch {
+		var err error
+		if doc, err = apply(doc, op); err != nil {
+			return nil, fmt.Errorf("invalid patch: operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+// Diff returns a patch that transforms a into b, both (decoded) JSON values.
+func Diff(a, b any) (Patch, error) {
+	var p Patch
+	if err := diff(&p, "", a, b); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func add(doc any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	key := tokens[len(tokens)-1]
+	return modify(doc, tokens[:len(tokens)-1], func(parent any) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			p[key] = value
+			return p, nil
+		case []any:
+			if key == "-" {
+				return append(p, value), nil
+			}
+			i, err := index(key, len(p)+1)
+			if err != nil {
+				return nil, err
+			}
+			p = append(p, nil)
+			copy(p[i+1:], p[i:])
+			p[i] = value
+			return p, nil
+		default:
+			return nil, fmt.Errorf("%q is not a container", key)
+		}
+	})
+}
+
+func apply(doc any, op Operation) (any, error) {
+	tokens, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	switch op.Op {
+	case "add", "replace", "test":
+		if len(op.Value) == 0 {
+			return nil, fmt.Errorf("missing value")
+		}
+		d := json.NewDecoder(bytes.NewReader(op.Value))
+		d.UseNumber()
+		if err := d.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+	switch op.Op {
+	case "add":
+		return add(doc, tokens, value)
+	case "remove":
+		doc, _, err := remove(doc, tokens)
+		return doc, err
+	case "replace":
+		if len(tokens) == 0 {
+			return value, nil
+		}
+		doc, _, err := remove(doc, tokens)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, tokens, value)
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			v, err := get(doc, from)
+			if err != nil {
+				return nil, err
+			}
+			return add(doc, tokens, deepCopy(v))
+		}
+		if op.Path != op.From && strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("can not move %s into one of its children", op.From)
+		}
+		doc, v, err := remove(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, tokens, v)
+	case "test":
+		v, err := get(doc, tokens)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(v, value) {
+			return nil, fmt.Errorf("test failed")
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unknown operation %q", op.Op)
+	}
+}
+
+func deepCopy(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[k] = deepCopy(e)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, e := range v {
+			s[i] = deepCopy(e)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+func diff(p *Patch, path string, a, b any) error {
+	switch a := a.(type) {
+	case map[string]any:
+		if b, ok := b.(map[string]any); ok {
+			for _, k := range sortedKeys(a) {
+				if v, ok := b[k]; ok {
+					if err := diff(p, path+"/"+escape(k), a[k], v); err != nil {
+						return err
+					}
+				} else {
+					*p = append(*p, Operation{Op: "remove", Path: path + "/" + escape(k)})
+				}
+			}
+			for _, k := range sortedKeys(b) {
+				if _, ok := a[k]; !ok {
+					if err := p.add("add", path+"/"+escape(k), b[k]); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+	case []any:
+		if b, ok := b.([]any); ok {
+			n := len(a)
+			if len(b) < n {
+				n = len(b)
+			}
+			for i := 0; i < n; i++ {
+				if err := diff(p, fmt.Sprintf("%s/%d", path, i), a[i], b[i]); err != nil {
+					return err
+				}
+			}
+			for i := len(a) - 1; n <= i; i-- {
+				*p = append(*p, Operation{Op: "remove", Path: fmt.Sprintf("%s/%d", path, i)})
+			}
+			for i := n; i < len(b); i++ {
+				if err := p.add("add", fmt.Sprintf("%s/%d", path, i), b[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	if equal(a, b) {
+		return nil
+	}
+	return p.add("replace", path, b)
+}
+
+// equal checks whether both JSON values are equal, numbers are compared by their value.
+// DOCS: https://www.rfc-editor.org/rfc/rfc6902#section-4.6
+func equal(a, b any) bool {
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, v := range a {
+			w, ok := b[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, ok := new(big.Rat).SetString(a.String())
+		if !ok {
+			return false
+		}
+		y, ok := new(big.Rat).SetString(b.String())
+		return ok && x.Cmp(y) == 0
+	default:
+		return a == b
+	}
+}
+
+// escape escapes a reference token of a JSON pointer.
+// DOCS: https://www.rfc-editor.org/rfc/rfc6901#section-4
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func get(doc any, tokens []string) (any, error) {
+	for _, token := range tokens {
+		switch d := doc.(type) {
+		case map[string]any:
+			v, ok := d[token]
+			if !ok {
+				return nil, fmt.Errorf("%q does not exist", token)
+			}
+			doc = v
+		case []any:
+			i, err := index(token, len(d))
+			if err != nil {
+				return nil, err
+			}
+			doc = d[i]
+		default:
+			return nil, fmt.Errorf("%q is not a container", token)
+		}
+	}
+	return doc, nil
+}
+
+// index parses an array index, which must be less than n.
+func index(token string, n int) (int, error) {
+	if token == "" || strings.Trim(token, "0123456789") != "" || (1 < len(token) && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || n <= i {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	return i, nil
+}
+
+// modify replaces the value at the given location with the result of fn.
+func modify(doc any, tokens []string, fn func(v any) (any, error)) (any, error) {
+	if len(tokens) == 0 {
+		return fn(doc)
+	}
+	switch d := doc.(type) {
+	case map[string]any:
+		v, ok := d[tokens[0]]
+		if !ok {
+			return nil, fmt.Errorf("%q does not exist", tokens[0])
+		}
+		v, err := modify(v, tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		d[tokens[0]] = v
+		return d, nil
+	case []any:
+		i, err := index(tokens[0], len(d))
+		if err != nil {
+			return nil, err
+		}
+		v, err := modify(d[i], tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		d[i] = v
+		return d, nil
+	default:
+		return nil, fmt.Errorf("%q is not a container", tokens[0])
+	}
+}
+
+// parsePointer parses a JSON pointer into its (unescaped) reference tokens.
+// DOCS: https://www.rfc-editor.org/rfc/rfc6901
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// remove removes the value at the given location, returning the result and the removed value.
+func remove(doc any, tokens []string) (any, any, error) {
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("can not remove the root")
+	}
+	key := tokens[len(tokens)-1]
+	var removed any
+	doc, err := modify(doc, tokens[:len(tokens)-1], func(parent any) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			v, ok := p[key]
+			if !ok {
+				return nil, fmt.Errorf("%q does not exist", key)
+			}
+			removed = v
+			delete(p, key)
+			return p, nil
+		case []any:
+			i, err := index(key, len(p))
+			if err != nil {
+				return nil, err
+			}
+			removed = p[i]
+			return append(p[:i], p[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%q is not a container", key)
+		}
+	})
+	return doc, removed, err
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (p *Patch) add(op, path string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	*p = append(*p, Operation{Op: op, Path: path, Value: raw})
+	return nil
+}
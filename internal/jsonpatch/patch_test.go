@@ -0,0 +1,107 @@
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func decode(t *testing.T, raw string) any {
+	var v any
+	d := json.NewDecoder(bytes.NewReader([]byte(raw)))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestApply(t *testing.T) {
+	// SOURCE: https://www.rfc-editor.org/rfc/rfc6902#appendix-A
+	for _, test := range []struct {
+		doc, patch, expected string
+	}{
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"baz": "qux", "foo": "bar"}`},
+		{`{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`},
+		{`{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo": "bar"}`},
+		{`{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`},
+		{`{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`},
+		{`{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`, `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`, `{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`},
+		{`{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`, `{"foo": ["all", "cows", "eat", "grass"]}`},
+		{`{"baz": "qux", "foo": ["a", 2, "c"]}`, `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2.0}]`, `{"baz": "qux", "foo": ["a", 2, "c"]}`},
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`, `{"foo": "bar", "child": {"grandchild": {}}}`},
+		{`{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo": ["bar", ["abc", "def"]]}`},
+		{`{"/": 1, "m~n": 2}`, `[{"op": "copy", "from": "/m~0n", "path": "/~1"}]`, `{"/": 2, "m~n": 2}`},
+		{`{"foo": null}`, `[{"op": "test", "path": "/foo", "value": null}]`, `{"foo": null}`},
+	} {
+		var patch Patch
+		if err := json.Unmarshal([]byte(test.patch), &patch); err != nil {
+			t.Fatal(err)
+		}
+		doc := decode(t, test.doc)
+		v, err := Apply(doc, patch)
+		if err != nil {
+			t.Fatal(test.patch, err)
+		}
+		if !equal(v, decode(t, test.expected)) {
+			t.Error(test.patch, v)
+		}
+		if !equal(doc, decode(t, test.doc)) {
+			t.Error("the document was modified", doc)
+		}
+	}
+}
+
+func TestApply_errors(t *testing.T) {
+	// SOURCE: https://www.rfc-editor.org/rfc/rfc6902#appendix-A
+	for _, test := range []struct {
+		doc, patch string
+	}{
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`},
+		{`{"baz": "qux"}`, `[{"op": "test", "path": "/baz", "value": "bar"}]`},
+		{`{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/2", "value": "qux"}]`},
+		{`{"foo": ["bar"]}`, `[{"op": "remove", "path": "/foo/01"}]`},
+		{`{"foo": {"bar": 1}}`, `[{"op": "move", "from": "/foo", "path": "/foo/bar/baz"}]`},
+		{`{"foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "qux"}]`},
+		{`{"foo": "bar"}`, `[{"op": "add", "path": "/baz"}]`},
+		{`{"foo": "bar"}`, `[{"op": "unknown", "path": "/foo"}]`},
+	} {
+		var patch Patch
+		if err := json.Unmarshal([]byte(test.patch), &patch); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Apply(decode(t, test.doc), patch); err == nil {
+			t.Error("expected an error", test.patch)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+	}{
+		{`{"foo": "bar"}`, `{"foo": "bar"}`},
+		{`{"foo": "bar", "a/b": 1}`, `{"baz": "qux", "a/b": 1.0}`},
+		{`{"foo": [1, 2, 3]}`, `{"foo": [1, 4]}`},
+		{`{"foo": [1]}`, `{"foo": [1, {"bar": null}, 3]}`},
+		{`{"foo": [1]}`, `{"foo": {"0": 1}}`},
+		{`[]`, `{}`},
+	} {
+		a, b := decode(t, test.a), decode(t, test.b)
+		patch, err := Diff(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, err := Apply(a, patch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equal(v, b) {
+			t.Error(test.a, test.b, v)
+		}
+	}
+	patch, _ := Diff(decode(t, `{"a": 1}`), decode(t, `{"a": 1.0}`))
+	if len(patch) != 0 {
+		t.Error(patch)
+	}
+}
@@ -1,10 +1,33 @@
 package jsonutils
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 )
 
+// Decode decodes a JSON value, keeping numbers as json.Number so they are re-encoded as is.
+func Decode(raw []byte) (any, error) {
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DecodeObject decodes a JSON object, keeping numbers as json.Number so they are re-encoded as is.
+func DecodeObject(raw []byte) (map[string]any, error) {
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	var m map[string]any
+	if err := d.Decode(&m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func FlattenStringOrSetOrMap(m map[string]any, fields []FlattenField) error {
 	for _, field := range fields {
 		k := field.Name
@@ -35,6 +58,30 @@ func FlattenStringOrSetOrMap(m map[string]any, fields []FlattenField) error {
 	return nil
 }
 
+// MergeExtensions adds the extension properties to the map, without overriding any of the existing properties.
+func MergeExtensions(m map[string]any, extensions map[string]json.RawMessage) {
+	for k, v := range extensions {
+		if _, ok := m[k]; !ok {
+			m[k] = v
+		}
+	}
+}
+
+// UnknownFields returns all fields of the map that are not one of the known keys, or nil if there are none.
+func UnknownFields(m map[string]json.RawMessage, known []string) map[string]json.RawMessage {
+	var unknown map[string]json.RawMessage
+	for k, v := range m {
+		if containsString(known, k) {
+			continue
+		}
+		if unknown == nil {
+			unknown = make(map[string]json.RawMessage)
+		}
+		unknown[k] = v
+	}
+	return unknown
+}
+
 func UnmarshalFields(m map[string]json.RawMessage, fields []UnmarshalField) error {
 	for _, field := range fields {
 		raw, ok := m[field.Key]
@@ -51,26 +98,6 @@ func UnmarshalFields(m map[string]json.RawMessage, fields []UnmarshalField) erro
 	return nil
 }
 
-func UnmarshalTOrSetOrMap(raw json.RawMessage, target *map[string]string) error {
-	if err := json.Unmarshal(raw, target); err == nil {
-		return nil
-	}
-	var a []string
-	if err := json.Unmarshal(raw, &a); err == nil {
-		*target = make(map[string]string, len(a))
-		for _, a := range a {
-			(*target)[a] = ""
-		}
-		return nil
-	}
-	var s string
-	if err := json.Unmarshal(raw, &s); err != nil {
-		return err
-	}
-	*target = map[string]string{s: ""}
-	return nil
-}
-
 func UnmarshalTOrSets[T any](m map[string]json.RawMessage, sets []UnmarshalTOrSet[T]) error {
 	for _, set := range sets {
 		raw, ok := m[set.Key]
@@ -94,6 +121,15 @@ func UnmarshalTOrSets[T any](m map[string]json.RawMessage, sets []UnmarshalTOrSe
 	return nil
 }
 
+func containsString(s []string, v string) bool {
+	for _, s := range s {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
 type FlattenField struct {
 	Name     string
 	Optional bool
@@ -0,0 +1,63 @@
+package multiformats
+
+import (
+	"fmt"
+	"math/big"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// DecodeBase58 decodes a string using the bitcoin base58 alphabet.
+func DecodeBase58(s string) ([]byte, error) {
+	var zeros int
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i := zeros; i < len(s); i++ {
+		j := indexBase58(s[i])
+		if j < 0 {
+			return nil, fmt.Errorf("invalid base58 character: %q", s[i])
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(j)))
+	}
+	var b []byte
+	if n.Sign() != 0 {
+		b = n.Bytes()
+	}
+	return append(make([]byte, zeros), b...), nil
+}
+
+// EncodeBase58 encodes the bytes using the bitcoin base58 alphabet.
+func EncodeBase58(b []byte) string {
+	var zeros int
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+	n := new(big.Int).SetBytes(b[zeros:])
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var s []byte
+	for n.Sign() != 0 {
+		n.DivMod(n, radix, mod)
+		s = append(s, base58Alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		s = append(s, base58Alphabet[0])
+	}
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+	return string(s)
+}
+
+func indexBase58(c byte) int {
+	for i := 0; i < len(base58Alphabet); i++ {
+		if base58Alphabet[i] == c {
+			return i
+		}
+	}
+	return -1
+}
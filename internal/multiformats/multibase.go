@@ -0,0 +1,74 @@
+package multiformats
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Base16 is the lowercase hexadecimal multibase encoding.
+	Base16 Encoding = 'f'
+	// Base32 is the lowercase RFC 4648 base32 multibase encoding without padding.
+	Base32 Encoding = 'b'
+	// Base58BTC is the bitcoin base58 multibase encoding.
+	Base58BTC Encoding = 'z'
+	// Base64 is the RFC 4648 base64 multibase encoding without padding.
+	Base64 Encoding = 'm'
+	// Base64URL is the RFC 4648 base64url multibase encoding without padding.
+	Base64URL Encoding = 'u'
+)
+
+// DecodeMultibase decodes a multibase encoded string.
+// DOCS: https://datatracker.ietf.org/doc/html/draft-multiformats-multibase
+func DecodeMultibase(s string) (Encoding, []byte, error) {
+	if s == "" {
+		return 0, nil, fmt.Errorf("empty multibase string")
+	}
+	encoding, data := Encoding(s[0]), s[1:]
+	var b []byte
+	var err error
+	switch encoding {
+	case Base16:
+		b, err = hex.DecodeString(data)
+	case Base32:
+		b, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(data))
+	case Base58BTC:
+		b, err = DecodeBase58(data)
+	case Base64:
+		b, err = base64.RawStdEncoding.DecodeString(data)
+	case Base64URL:
+		b, err = base64.RawURLEncoding.DecodeString(data)
+	default:
+		return 0, nil, fmt.Errorf("unsupported multibase encoding: %q", s[0])
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return encoding, b, nil
+}
+
+// EncodeMultibase encodes the bytes using the given multibase encoding.
+func EncodeMultibase(encoding Encoding, b []byte) (string, error) {
+	var data string
+	switch encoding {
+	case Base16:
+		data = hex.EncodeToString(b)
+	case Base32:
+		data = strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+	case Base58BTC:
+		data = EncodeBase58(b)
+	case Base64:
+		data = base64.RawStdEncoding.EncodeToString(b)
+	case Base64URL:
+		data = base64.RawURLEncoding.EncodeToString(b)
+	default:
+		return "", fmt.Errorf("unsupported multibase encoding: %q", byte(encoding))
+	}
+	return string(encoding) + data, nil
+}
+
+// Encoding is a multibase encoding, identified by its prefix character.
+type Encoding byte
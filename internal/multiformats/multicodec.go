@@ -0,0 +1,30 @@
+package multiformats
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Multicodec codes of public keys.
+// DOCS: https://github.com/multiformats/multicodec/blob/master/table.csv
+const (
+	Ed25519Pub   uint64 = 0xed
+	P256Pub      uint64 = 0x1200
+	P384Pub      uint64 = 0x1201
+	Secp256k1Pub uint64 = 0xe7
+	X25519Pub    uint64 = 0xec
+)
+
+// DecodeMulticodec decodes the multicodec prefix of the given bytes, returning the code and the remaining bytes.
+func DecodeMulticodec(b []byte) (uint64, []byte, error) {
+	code, b, err := ReadUvarint(b)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid multicodec: %w", err)
+	}
+	return code, b, nil
+}
+
+// EncodeMulticodec prefixes the given bytes with the (varint encoded) multicodec code.
+func EncodeMulticodec(code uint64, b []byte) []byte {
+	return append(binary.AppendUvarint(nil, code), b...)
+}
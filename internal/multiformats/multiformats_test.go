@@ -0,0 +1,65 @@
+package multiformats
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBase58(t *testing.T) {
+	for _, test := range []struct {
+		raw     []byte
+		encoded string
+	}{
+		{[]byte{}, ""},
+		{[]byte{0}, "1"},
+		{[]byte{0, 0, 1}, "112"},
+		{[]byte("hello world"), "StV1DL6CwTryKyV"},
+	} {
+		if s := EncodeBase58(test.raw); s != test.encoded {
+			t.Error(s, test.encoded)
+		}
+		b, err := DecodeBase58(test.encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, test.raw) {
+			t.Error(b, test.raw)
+		}
+	}
+	if _, err := DecodeBase58("0OIl"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestDecodeMultihash(t *testing.T) {
+	// SOURCE: https://datatracker.ietf.org/doc/html/draft-sporny-hashlink
+	_, b, err := DecodeMultibase("zQmWvQxTqbG2Z9HPJgG57jjwR154cKhbtJenbyYTWkjgF3e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, digest, err := DecodeMultihash(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != 0x12 || len(digest) != 32 {
+		t.Error(code, len(digest))
+	}
+}
+
+func TestDecodeMulticodec(t *testing.T) {
+	// SOURCE: https://w3c-ccg.github.io/did-method-key/#example-a-simple-ed25519-did-key-value
+	_, b, err := DecodeMultibase("z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, key, err := DecodeMulticodec(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != Ed25519Pub || len(key) != 32 {
+		t.Error(code, len(key))
+	}
+	if !bytes.Equal(EncodeMulticodec(code, key), b) {
+		t.Error(b)
+	}
+}
@@ -0,0 +1,33 @@
+package multiformats
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// DecodeMultihash decodes a multihash into its hash function code and digest.
+// DOCS: https://datatracker.ietf.org/doc/html/draft-multiformats-multihash
+func DecodeMultihash(b []byte) (uint64, []byte, error) {
+	code, b, err := ReadUvarint(b)
+	if err != nil {
+		return 0, nil, err
+	}
+	length, b, err := ReadUvarint(b)
+	if err != nil {
+		return 0, nil, err
+	}
+	if uint64(len(b)) != length {
+		return 0, nil, fmt.Errorf("invalid multihash: digest length %d does not match %d", len(b), length)
+	}
+	return code, b, nil
+}
+
+// ReadUvarint reads an unsigned varint from the given bytes, returning the value and the remaining bytes.
+// DOCS: https://github.com/multiformats/unsigned-varint
+func ReadUvarint(b []byte) (uint64, []byte, error) {
+	v, n := binary.Uvarint(b)
+	if n <= 0 || 9 < n {
+		return 0, nil, fmt.Errorf("invalid varint")
+	}
+	return v, b[n:], nil
+}